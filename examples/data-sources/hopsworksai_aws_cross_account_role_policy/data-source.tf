@@ -0,0 +1,22 @@
+# default cross-account role policies
+data "hopsworksai_aws_cross_account_role_policy" "policy" {
+  external_id = "my-external-id"
+}
+
+# limit the roles that can be passed to the cluster nodes and use your own VPC
+data "hopsworksai_aws_cross_account_role_policy" "policy" {
+  external_id                = "my-external-id"
+  instance_profile_role_arns = ["arn:aws:iam::000011112222:role/my-instance-profile-role"]
+  enable_vpc_creation        = false
+}
+
+resource "aws_iam_role" "cross_account_role" {
+  name               = "hopsworksai-cross-account-role"
+  assume_role_policy = data.hopsworksai_aws_cross_account_role_policy.policy.trust_policy_json
+}
+
+resource "aws_iam_role_policy" "cross_account_policy" {
+  name   = "hopsworksai-cross-account-policy"
+  role   = aws_iam_role.cross_account_role.id
+  policy = data.hopsworksai_aws_cross_account_role_policy.policy.json
+}
@@ -13,4 +13,11 @@ data "hopsworksai_aws_instance_profile_policy" "policy" {
 data "hopsworksai_aws_instance_profile_policy" "policy" {
   enable_eks = false
   enable_ecr = false
+}
+
+# add permissions for S3 buckets and EBS volumes encrypted with customer managed KMS keys
+data "hopsworksai_aws_instance_profile_policy" "policy" {
+  region          = "us-east-2"
+  kms_key_arns    = ["arn:aws:kms:us-east-2:000011112222:key/s3-key-id"]
+  ebs_kms_key_arn = "arn:aws:kms:us-east-2:000011112222:key/ebs-key-id"
 }
\ No newline at end of file
@@ -0,0 +1,10 @@
+# check a pre-approved policy and fail the plan if any required action is missing
+data "hopsworksai_aws_policy_check" "check" {
+  policy_json     = file("${path.module}/approved-policy.json")
+  bucket_name     = "my-bucket"
+  fail_on_missing = true
+}
+
+output "over_broad_resources" {
+  value = data.hopsworksai_aws_policy_check.check.over_broad_resources
+}
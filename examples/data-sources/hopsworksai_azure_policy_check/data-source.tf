@@ -0,0 +1,10 @@
+# check the actions of an existing role definition
+data "hopsworksai_azure_policy_check" "check" {
+  actions      = azurerm_role_definition.role.permissions[0].actions
+  not_actions  = azurerm_role_definition.role.permissions[0].not_actions
+  data_actions = azurerm_role_definition.role.permissions[0].data_actions
+}
+
+output "missing_actions" {
+  value = data.hopsworksai_azure_policy_check.check.missing_actions
+}
@@ -6,4 +6,32 @@ data "hopsworksai_azure_user_assigned_identity_permissions" "permissions" {
 # disable backup permissions
 data "hopsworksai_azure_user_assigned_identity_permissions" "permissions" {
   enable_backup = false
+}
+
+# limit the permissions to a single storage container and output a role definition
+data "hopsworksai_azure_user_assigned_identity_permissions" "permissions" {
+  storage_account_id     = azurerm_storage_account.storage.id
+  storage_container_name = "hopsworks"
+  acr_id                 = azurerm_container_registry.acr.id
+  enable_aks             = false
+  minimal_permissions    = true
+}
+
+resource "azurerm_role_definition" "role" {
+  name              = data.hopsworksai_azure_user_assigned_identity_permissions.permissions.role_name
+  scope             = data.hopsworksai_azure_user_assigned_identity_permissions.permissions.assignable_scopes[0]
+  assignable_scopes = data.hopsworksai_azure_user_assigned_identity_permissions.permissions.assignable_scopes
+  permissions {
+    actions          = data.hopsworksai_azure_user_assigned_identity_permissions.permissions.actions
+    not_actions      = data.hopsworksai_azure_user_assigned_identity_permissions.permissions.not_actions
+    data_actions     = data.hopsworksai_azure_user_assigned_identity_permissions.permissions.data_actions
+    not_data_actions = data.hopsworksai_azure_user_assigned_identity_permissions.permissions.not_data_actions
+  }
+}
+
+resource "azurerm_role_assignment" "role_assignment" {
+  for_each           = toset(data.hopsworksai_azure_user_assigned_identity_permissions.permissions.role_assignment_scopes)
+  scope              = each.value
+  role_definition_id = azurerm_role_definition.role.role_definition_resource_id
+  principal_id       = azurerm_user_assigned_identity.identity.principal_id
 }
\ No newline at end of file
@@ -1,4 +1,16 @@
-
-data "hopsworksai_clusters" "cluster" {
+data "hopsworksai_cluster" "cluster" {
   cluster_id = "CLUSTER ID"
-}
\ No newline at end of file
+}
+
+# or lookup the cluster using its name
+data "hopsworksai_cluster" "cluster_by_name" {
+  name = "CLUSTER NAME"
+}
+
+# or lookup the cluster using its tags
+data "hopsworksai_cluster" "cluster_by_tags" {
+  tags = {
+    "team" = "data"
+    "env"  = "prod"
+  }
+}
@@ -0,0 +1,53 @@
+# estimate the cost of a cluster with auto scaling and spot workers before creating it
+data "hopsworksai_cluster_cost_estimate" "estimate" {
+  cloud_provider = "AWS"
+  region         = "us-east-2"
+
+  head {
+    instance_type = "m5.2xlarge"
+  }
+
+  autoscale {
+    non_gpu_workers {
+      instance_type = "m5.2xlarge"
+      min_workers   = 0
+      max_workers   = 10
+      spot_config {
+        max_price_percent = 70
+      }
+    }
+  }
+
+  rondb {
+    single_node {
+      instance_type = "r5.2xlarge"
+    }
+  }
+}
+
+output "monthly_cost_range" {
+  value = "${data.hopsworksai_cluster_cost_estimate.estimate.min_monthly_cost} - ${data.hopsworksai_cluster_cost_estimate.estimate.max_monthly_cost}"
+}
+
+# estimate the cost offline using a price table kept in a json file
+data "hopsworksai_cluster_cost_estimate" "offline" {
+  cloud_provider = "AWS"
+  region         = "us-east-2"
+  offline        = true
+  price_table    = jsondecode(file("${path.module}/prices.json"))
+
+  head {
+    instance_type = "m5.2xlarge"
+  }
+
+  workers {
+    instance_type = "m5.2xlarge"
+    count         = 2
+  }
+
+  rondb {
+    single_node {
+      instance_type = "r5.2xlarge"
+    }
+  }
+}
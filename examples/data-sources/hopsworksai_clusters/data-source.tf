@@ -8,4 +8,22 @@ data "hopsworksai_clusters" "awsClusters" {
   filter {
     cloud = "AWS"
   }
-}
\ No newline at end of file
+}
+
+# retrieve running 3.7 clusters in eu-west-1 tagged with team=ml
+data "hopsworksai_clusters" "mlClusters" {
+  filter {
+    cloud   = "AWS"
+    state   = "running"
+    version = ">= 3.7, < 3.8"
+    region  = "eu-west-1"
+    tags = {
+      "team" = "ml"
+    }
+  }
+}
+
+# retrieve only the ids and names of the clusters
+data "hopsworksai_clusters" "ids" {
+  ids_only = true
+}
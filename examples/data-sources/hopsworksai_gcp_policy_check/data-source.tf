@@ -0,0 +1,5 @@
+# check the permissions of an existing custom role
+data "hopsworksai_gcp_policy_check" "check" {
+  permissions     = google_project_iam_custom_role.role.permissions
+  fail_on_missing = true
+}
@@ -0,0 +1,32 @@
+# default permissions
+data "hopsworksai_gcp_service_account_custom_role_permissions" "permissions" {
+
+}
+
+# add GKE and logging permissions and limit storage permissions to a single bucket
+data "hopsworksai_gcp_service_account_custom_role_permissions" "permissions" {
+  enable_gke     = true
+  enable_logging = true
+  bucket_name    = "my-bucket"
+}
+
+resource "google_project_iam_custom_role" "role" {
+  role_id     = "hopsworksai"
+  title       = data.hopsworksai_gcp_service_account_custom_role_permissions.permissions.title
+  stage       = data.hopsworksai_gcp_service_account_custom_role_permissions.permissions.stage
+  permissions = data.hopsworksai_gcp_service_account_custom_role_permissions.permissions.permissions
+}
+
+resource "google_project_iam_member" "member" {
+  project = "my-project"
+  role    = google_project_iam_custom_role.role.id
+  member  = "serviceAccount:${google_service_account.service_account.email}"
+  dynamic "condition" {
+    for_each = data.hopsworksai_gcp_service_account_custom_role_permissions.permissions.condition
+    content {
+      title       = condition.value.title
+      description = condition.value.description
+      expression  = condition.value.expression
+    }
+  }
+}
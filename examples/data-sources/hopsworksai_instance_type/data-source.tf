@@ -12,4 +12,15 @@ data "hopsworksai_instance_type" "supported_type" {
   region         = "us-east-2"
   min_memory_gb  = 32
   min_cpus       = 16
-}
\ No newline at end of file
+}
+
+# retrieve the cheapest supported instance type for worker node with at least one NVIDIA T4 GPU and fail if none is available
+data "hopsworksai_instance_type" "gpu_worker" {
+  node_type        = "worker"
+  cloud_provider   = "AWS"
+  region           = "us-east-2"
+  min_gpus         = 1
+  gpu_type         = "nvidia-tesla-t4"
+  strategy         = "cheapest"
+  fail_if_no_match = true
+}
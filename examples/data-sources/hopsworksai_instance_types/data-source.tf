@@ -3,4 +3,21 @@ data "hopsworksai_instance_types" "supported_worker_types" {
   node_type      = "head"
   cloud_provider = "AWS"
   region         = "us-east-2"
-}
\ No newline at end of file
+}
+
+# retrieve the supported x86_64 instance types with at least 16 GB of memory for head and workers in one call, cheapest first
+data "hopsworksai_instance_types" "supported_types" {
+  node_types     = ["head", "worker"]
+  cloud_provider = "AWS"
+  region         = "us-east-2"
+
+  filter {
+    min_memory_gb = 16
+    architecture  = "x86_64"
+    exclude       = ["t3.*"]
+  }
+
+  sort {
+    key = "price"
+  }
+}
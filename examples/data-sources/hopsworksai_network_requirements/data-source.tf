@@ -0,0 +1,39 @@
+data "hopsworksai_network_requirements" "rules" {
+  cloud_provider = "AWS"
+  open_ports {
+    feature_store        = true
+    online_feature_store = true
+  }
+  allowed_cidrs = ["10.1.0.0/16"]
+  open_ports_allowed_cidrs {
+    online_feature_store = ["10.2.0.0/16"]
+  }
+}
+
+resource "aws_security_group" "security_group" {
+  name   = "hopsworks-security-group"
+  vpc_id = aws_vpc.vpc.id
+}
+
+resource "aws_security_group_rule" "ingress" {
+  count             = length(data.hopsworksai_network_requirements.rules.ingress_rules)
+  security_group_id = aws_security_group.security_group.id
+  type              = "ingress"
+  description       = data.hopsworksai_network_requirements.rules.ingress_rules[count.index].description
+  protocol          = data.hopsworksai_network_requirements.rules.ingress_rules[count.index].protocol
+  from_port         = data.hopsworksai_network_requirements.rules.ingress_rules[count.index].from_port
+  to_port           = data.hopsworksai_network_requirements.rules.ingress_rules[count.index].to_port
+  self              = data.hopsworksai_network_requirements.rules.ingress_rules[count.index].self ? true : null
+  cidr_blocks       = data.hopsworksai_network_requirements.rules.ingress_rules[count.index].self ? null : data.hopsworksai_network_requirements.rules.ingress_rules[count.index].cidr_blocks
+}
+
+resource "aws_security_group_rule" "egress" {
+  count             = length(data.hopsworksai_network_requirements.rules.egress_rules)
+  security_group_id = aws_security_group.security_group.id
+  type              = "egress"
+  description       = data.hopsworksai_network_requirements.rules.egress_rules[count.index].description
+  protocol          = data.hopsworksai_network_requirements.rules.egress_rules[count.index].protocol
+  from_port         = data.hopsworksai_network_requirements.rules.egress_rules[count.index].from_port
+  to_port           = data.hopsworksai_network_requirements.rules.egress_rules[count.index].to_port
+  cidr_blocks       = data.hopsworksai_network_requirements.rules.egress_rules[count.index].cidr_blocks
+}
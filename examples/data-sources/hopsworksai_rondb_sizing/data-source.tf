@@ -0,0 +1,58 @@
+data "hopsworksai_rondb_sizing" "online_fs" {
+  cloud_provider = "AWS"
+  region         = "us-east-2"
+  expected_rows  = 500000000
+  row_size_bytes = 200
+  feature_groups = 20
+  read_qps       = 20000
+  write_qps      = 5000
+  availability   = "high"
+}
+
+resource "hopsworksai_cluster" "cluster" {
+  name = "my-cluster"
+
+  head {
+    instance_type = "m5.2xlarge"
+  }
+
+  aws_attributes {
+    region = "us-east-2"
+    bucket {
+      name = "my-bucket"
+    }
+    instance_profile_arn = "arn:aws:iam::000000000000:instance-profile/my-instance-profile"
+  }
+
+  rondb {
+    configuration {
+      ndbd_default {
+        replication_factor = data.hopsworksai_rondb_sizing.online_fs.replication_factor
+      }
+    }
+
+    management_nodes {
+      instance_type = data.hopsworksai_rondb_sizing.online_fs.rondb.0.management_nodes.0.instance_type
+    }
+
+    data_nodes {
+      instance_type = data.hopsworksai_rondb_sizing.online_fs.rondb.0.data_nodes.0.instance_type
+      count         = data.hopsworksai_rondb_sizing.online_fs.rondb.0.data_nodes.0.count
+      disk_size     = data.hopsworksai_rondb_sizing.online_fs.rondb.0.data_nodes.0.disk_size
+    }
+
+    mysql_nodes {
+      instance_type = data.hopsworksai_rondb_sizing.online_fs.rondb.0.mysql_nodes.0.instance_type
+      count         = data.hopsworksai_rondb_sizing.online_fs.rondb.0.mysql_nodes.0.count
+    }
+
+    api_nodes {
+      instance_type = data.hopsworksai_rondb_sizing.online_fs.rondb.0.api_nodes.0.instance_type
+      count         = data.hopsworksai_rondb_sizing.online_fs.rondb.0.api_nodes.0.count
+    }
+  }
+}
+
+output "single_node_capable" {
+  value = data.hopsworksai_rondb_sizing.online_fs.single_node_capable
+}
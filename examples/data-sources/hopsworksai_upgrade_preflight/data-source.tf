@@ -0,0 +1,8 @@
+data "hopsworksai_upgrade_preflight" "preflight" {
+  cluster_id     = hopsworksai_cluster.cluster.id
+  target_version = "3.9.0"
+}
+
+output "failed_checks" {
+  value = [for check in data.hopsworksai_upgrade_preflight.preflight.checks : check.message if !check.passed]
+}
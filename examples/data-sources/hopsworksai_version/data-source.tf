@@ -14,4 +14,10 @@ data "hopsworksai_version" "latest" {
 data "hopsworksai_version" "latest" {
   cloud_provider           = "AWS"
   upgradeable_from_version = "2.1.0"
+}
+
+# retrieve latest supported 3.x version starting from 3.8 on GCP
+data "hopsworksai_version" "latest" {
+  cloud_provider     = "GCP"
+  version_constraint = "~> 3.8"
 }
\ No newline at end of file
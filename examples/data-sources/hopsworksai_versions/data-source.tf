@@ -0,0 +1,14 @@
+# retrieve all supported versions on AWS
+data "hopsworksai_versions" "all" {
+  cloud_provider = "AWS"
+}
+
+# retrieve all 3.x versions starting from 3.4 on GCP
+data "hopsworksai_versions" "v3" {
+  cloud_provider     = "GCP"
+  version_constraint = ">= 3.4, < 4.0"
+}
+
+output "latest_v3" {
+  value = element(data.hopsworksai_versions.v3.ids, length(data.hopsworksai_versions.v3.ids) - 1)
+}
@@ -1 +1,5 @@
-terraform import hopsworksai_cluster.my_cluster <cluster_id>
\ No newline at end of file
+# import using the cluster id
+terraform import hopsworksai_cluster.my_cluster <cluster_id>
+
+# or using the cluster name
+terraform import hopsworksai_cluster.my_cluster <cluster_name>
\ No newline at end of file
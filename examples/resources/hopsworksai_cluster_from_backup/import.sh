@@ -0,0 +1,2 @@
+# import using the cluster id (or name) and the id of the backup it was restored from
+terraform import hopsworksai_cluster_from_backup.my_cluster <cluster_id>:<backup_id>
\ No newline at end of file
@@ -0,0 +1,287 @@
+package hopsworksai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceAWSCrossAccountRolePolicy() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get the aws cross-account role policies needed by Hopsworks.ai to manage clusters in your aws account. The permissions policy always includes iam:GetInstanceProfile and iam:SimulatePrincipalPolicy that Hopsworks.ai uses to validate the instance profile of your clusters before creating them.",
+		Schema: map[string]*schema.Schema{
+			"external_id": {
+				Description: "The external id that Hopsworks.ai uses when assuming the cross-account role.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"hopsworksai_account": {
+				Description: "The aws account of Hopsworks.ai that is allowed to assume the cross-account role.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "822623301872",
+			},
+			"instance_profile_role_arns": {
+				Description: "Limit the roles that Hopsworks.ai can pass to your cluster nodes to these role ARNs.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"enable_backup": {
+				Description: "Add permissions required to allow creating backups of your clusters.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"enable_ecr": {
+				Description: "Add permissions required to allow Hopsworks.ai to manage the ECR repositories of your clusters.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"enable_eks": {
+				Description: "Add permissions required to allow Hopsworks.ai to integrate your clusters with Amazon EKS.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"enable_vpc_creation": {
+				Description: "Add permissions required to allow Hopsworks.ai to create a VPC, subnets, and security groups for your clusters. Disable it if you always use your own VPC.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"partition": {
+				Description:  "The aws partition (aws, aws-cn, or aws-us-gov) used to build the resource ARNs.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "aws",
+				ValidateFunc: validation.StringInSlice([]string{"aws", "aws-cn", "aws-us-gov"}, false),
+			},
+			"json": {
+				Description: "The permissions policy of the cross-account role in JSON format.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"trust_policy_json": {
+				Description: "The trust policy of the cross-account role in JSON format.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+		ReadContext: dataSourceAWSCrossAccountRolePolicyRead,
+	}
+}
+
+func awsCrossAccountPassRolePermissions(passRoleResources interface{}) awsPolicyStatement {
+	return awsPolicyStatement{
+		Sid:    "HopsworksAIInstanceProfile",
+		Effect: "Allow",
+		Action: []string{
+			"iam:PassRole",
+		},
+		Resources: passRoleResources,
+	}
+}
+
+func awsCrossAccountInstanceProfileCheckPermissions(partition string) awsPolicyStatement {
+	return awsPolicyStatement{
+		Sid:    "HopsworksAIInstanceProfileCheck",
+		Effect: "Allow",
+		Action: []string{
+			"iam:GetInstanceProfile",
+			"iam:SimulatePrincipalPolicy",
+		},
+		Resources: []string{
+			fmt.Sprintf("arn:%s:iam::*:instance-profile/*", partition),
+			fmt.Sprintf("arn:%s:iam::*:role/*", partition),
+		},
+	}
+}
+
+func awsCrossAccountEC2Permissions() awsPolicyStatement {
+	return awsPolicyStatement{
+		Sid:    "HopsworksAIEC2",
+		Effect: "Allow",
+		Action: []string{
+			"ec2:RunInstances",
+			"ec2:StartInstances",
+			"ec2:StopInstances",
+			"ec2:TerminateInstances",
+			"ec2:DescribeInstances",
+			"ec2:DescribeInstanceStatus",
+			"ec2:DescribeInstanceTypes",
+			"ec2:DescribeInstanceTypeOfferings",
+			"ec2:DescribeImages",
+			"ec2:DescribeKeyPairs",
+			"ec2:DescribeRegions",
+			"ec2:DescribeAvailabilityZones",
+			"ec2:DescribeVpcs",
+			"ec2:DescribeSubnets",
+			"ec2:DescribeSecurityGroups",
+			"ec2:AuthorizeSecurityGroupIngress",
+			"ec2:RevokeSecurityGroupIngress",
+			"ec2:CreateVolume",
+			"ec2:AttachVolume",
+			"ec2:ModifyVolume",
+			"ec2:DescribeVolumes",
+			"ec2:CreateTags",
+		},
+		Resources: "*",
+	}
+}
+
+func awsCrossAccountVPCPermissions() awsPolicyStatement {
+	return awsPolicyStatement{
+		Sid:    "HopsworksAIVPC",
+		Effect: "Allow",
+		Action: []string{
+			"ec2:CreateVpc",
+			"ec2:ModifyVpcAttribute",
+			"ec2:DeleteVpc",
+			"ec2:CreateSubnet",
+			"ec2:DeleteSubnet",
+			"ec2:CreateInternetGateway",
+			"ec2:AttachInternetGateway",
+			"ec2:DetachInternetGateway",
+			"ec2:DeleteInternetGateway",
+			"ec2:DescribeInternetGateways",
+			"ec2:CreateRouteTable",
+			"ec2:CreateRoute",
+			"ec2:AssociateRouteTable",
+			"ec2:DeleteRouteTable",
+			"ec2:DescribeRouteTables",
+			"ec2:CreateSecurityGroup",
+			"ec2:DeleteSecurityGroup",
+		},
+		Resources: "*",
+	}
+}
+
+func awsCrossAccountBackupPermissions() awsPolicyStatement {
+	return awsPolicyStatement{
+		Sid:    "HopsworksAIBackup",
+		Effect: "Allow",
+		Action: []string{
+			"ec2:RegisterImage",
+			"ec2:DeregisterImage",
+			"ec2:CreateSnapshot",
+			"ec2:DeleteSnapshot",
+			"ec2:DescribeSnapshots",
+		},
+		Resources: "*",
+	}
+}
+
+func awsCrossAccountECRPermissions(partition string) awsPolicyStatement {
+	return awsPolicyStatement{
+		Sid:    "HopsworksAIECR",
+		Effect: "Allow",
+		Action: []string{
+			"ecr:DescribeRepositories",
+			"ecr:DeleteRepository",
+		},
+		Resources: fmt.Sprintf("arn:%s:ecr:*:*:repository/*", partition),
+	}
+}
+
+func awsCrossAccountEKSPermissions(partition string) awsPolicyStatement {
+	return awsPolicyStatement{
+		Sid:    "HopsworksAIEKS",
+		Effect: "Allow",
+		Action: []string{
+			"eks:DescribeCluster",
+		},
+		Resources: fmt.Sprintf("arn:%s:eks:*:*:cluster/*", partition),
+	}
+}
+
+func awsCrossAccountTrustPolicy(hopsworksaiAccount string, externalId string, partition string) awsPolicy {
+	return awsPolicy{
+		Version: "2012-10-17",
+		Statements: []awsPolicyStatement{
+			{
+				Effect: "Allow",
+				Principal: map[string]string{
+					"AWS": fmt.Sprintf("arn:%s:iam::%s:root", partition, hopsworksaiAccount),
+				},
+				Action: []string{
+					"sts:AssumeRole",
+				},
+				Condition: map[string]map[string]interface{}{
+					"StringEquals": {
+						"sts:ExternalId": externalId,
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAWSCrossAccountRolePolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	partition := d.Get("partition").(string)
+
+	var passRoleResources interface{} = fmt.Sprintf("arn:%s:iam::*:role/*", partition)
+	if v, ok := d.GetOk("instance_profile_role_arns"); ok {
+		roleArns := make([]string, 0)
+		for _, arn := range v.([]interface{}) {
+			roleArns = append(roleArns, arn.(string))
+		}
+		passRoleResources = roleArns
+	}
+
+	policy := awsPolicy{
+		Version: "2012-10-17",
+		Statements: []awsPolicyStatement{
+			awsCrossAccountPassRolePermissions(passRoleResources),
+			awsCrossAccountInstanceProfileCheckPermissions(partition),
+			awsCrossAccountEC2Permissions(),
+		},
+	}
+
+	if d.Get("enable_vpc_creation").(bool) {
+		policy.Statements = append(policy.Statements, awsCrossAccountVPCPermissions())
+	}
+
+	if d.Get("enable_backup").(bool) {
+		policy.Statements = append(policy.Statements, awsCrossAccountBackupPermissions())
+	}
+
+	if d.Get("enable_ecr").(bool) {
+		policy.Statements = append(policy.Statements, awsCrossAccountECRPermissions(partition))
+	}
+
+	if d.Get("enable_eks").(bool) {
+		policy.Statements = append(policy.Statements, awsCrossAccountEKSPermissions(partition))
+	}
+
+	policyJson, err := json.MarshalIndent(policy, "", "  ")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	trustPolicy := awsCrossAccountTrustPolicy(d.Get("hopsworksai_account").(string), d.Get("external_id").(string), partition)
+	trustPolicyJson, err := json.MarshalIndent(trustPolicy, "", "  ")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	policyString := string(policyJson)
+	trustPolicyString := string(trustPolicyJson)
+
+	d.SetId(strconv.Itoa(schema.HashString(policyString + trustPolicyString)))
+	if err := d.Set("json", policyString); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("trust_policy_json", trustPolicyString); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
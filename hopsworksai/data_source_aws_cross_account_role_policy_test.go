@@ -0,0 +1,151 @@
+package hopsworksai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/test"
+)
+
+func TestAccAWSCrossAccountRolePolicy_basic(t *testing.T) {
+	dataSourceName := "data.hopsworksai_aws_cross_account_role_policy.test"
+	policy := &awsPolicy{
+		Version: "2012-10-17",
+		Statements: []awsPolicyStatement{
+			awsCrossAccountPassRolePermissions("arn:aws:iam::*:role/*"),
+			awsCrossAccountInstanceProfileCheckPermissions("aws"),
+			awsCrossAccountEC2Permissions(),
+			awsCrossAccountVPCPermissions(),
+			awsCrossAccountBackupPermissions(),
+			awsCrossAccountECRPermissions("aws"),
+			awsCrossAccountEKSPermissions("aws"),
+		},
+	}
+	trustPolicy := awsCrossAccountTrustPolicy("822623301872", "external-id", "aws")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSCrossAccountRolePolicyConfig_basic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "json", testAccAWSPolicyToJSONString(t, policy)),
+					resource.TestCheckResourceAttr(dataSourceName, "trust_policy_json", testAccAWSPolicyToJSONString(t, &trustPolicy)),
+				),
+			},
+		},
+	})
+}
+
+func TestAWSCrossAccountRolePolicyRead_restricted(t *testing.T) {
+	r := test.ResourceFixture{
+		Resource:             dataSourceAWSCrossAccountRolePolicy(),
+		OperationContextFunc: dataSourceAWSCrossAccountRolePolicy().ReadContext,
+		State: map[string]interface{}{
+			"external_id":         "external-id",
+			"hopsworksai_account": "000011112222",
+			"enable_vpc_creation": false,
+			"enable_backup":       false,
+			"enable_ecr":          false,
+			"partition":           "aws-us-gov",
+			"instance_profile_role_arns": []interface{}{
+				"arn:aws-us-gov:iam::333344445555:role/my-role",
+			},
+		},
+		ExpectState: map[string]interface{}{
+			"json": `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "HopsworksAIInstanceProfile",
+      "Effect": "Allow",
+      "Action": [
+        "iam:PassRole"
+      ],
+      "Resource": [
+        "arn:aws-us-gov:iam::333344445555:role/my-role"
+      ]
+    },
+    {
+      "Sid": "HopsworksAIInstanceProfileCheck",
+      "Effect": "Allow",
+      "Action": [
+        "iam:GetInstanceProfile",
+        "iam:SimulatePrincipalPolicy"
+      ],
+      "Resource": [
+        "arn:aws-us-gov:iam::*:instance-profile/*",
+        "arn:aws-us-gov:iam::*:role/*"
+      ]
+    },
+    {
+      "Sid": "HopsworksAIEC2",
+      "Effect": "Allow",
+      "Action": [
+        "ec2:RunInstances",
+        "ec2:StartInstances",
+        "ec2:StopInstances",
+        "ec2:TerminateInstances",
+        "ec2:DescribeInstances",
+        "ec2:DescribeInstanceStatus",
+        "ec2:DescribeInstanceTypes",
+        "ec2:DescribeInstanceTypeOfferings",
+        "ec2:DescribeImages",
+        "ec2:DescribeKeyPairs",
+        "ec2:DescribeRegions",
+        "ec2:DescribeAvailabilityZones",
+        "ec2:DescribeVpcs",
+        "ec2:DescribeSubnets",
+        "ec2:DescribeSecurityGroups",
+        "ec2:AuthorizeSecurityGroupIngress",
+        "ec2:RevokeSecurityGroupIngress",
+        "ec2:CreateVolume",
+        "ec2:AttachVolume",
+        "ec2:ModifyVolume",
+        "ec2:DescribeVolumes",
+        "ec2:CreateTags"
+      ],
+      "Resource": "*"
+    },
+    {
+      "Sid": "HopsworksAIEKS",
+      "Effect": "Allow",
+      "Action": [
+        "eks:DescribeCluster"
+      ],
+      "Resource": "arn:aws-us-gov:eks:*:*:cluster/*"
+    }
+  ]
+}`,
+			"trust_policy_json": `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {
+        "AWS": "arn:aws-us-gov:iam::000011112222:root"
+      },
+      "Action": [
+        "sts:AssumeRole"
+      ],
+      "Condition": {
+        "StringEquals": {
+          "sts:ExternalId": "external-id"
+        }
+      }
+    }
+  ]
+}`,
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func testAccAWSCrossAccountRolePolicyConfig_basic() string {
+	return `
+	data "hopsworksai_aws_cross_account_role_policy" "test" {
+		external_id = "external-id"
+	}
+	`
+}
@@ -5,16 +5,20 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 type awsPolicyStatement struct {
 	Sid       string      `json:"Sid,omitempty"`
 	Effect    string      `json:"Effect,omitempty"`
+	Principal interface{} `json:"Principal,omitempty"`
 	Action    []string    `json:"Action,omitempty"`
 	Resources interface{} `json:"Resource,omitempty"`
+	Condition interface{} `json:"Condition,omitempty"`
 }
 
 type awsPolicy struct {
@@ -87,7 +91,7 @@ func dataSourceAWSInstanceProfilePolicy() *schema.Resource {
 				Computed:    true,
 			},
 			"region": {
-				Description: "Limit docker repository permissions to a region",
+				Description: "Limit docker repository and KMS permissions to a region",
 				Type:        schema.TypeString,
 				Optional:    true,
 			},
@@ -102,6 +106,26 @@ func dataSourceAWSInstanceProfilePolicy() *schema.Resource {
 				Optional:    true,
 				Default:     "822623301872",
 			},
+			"partition": {
+				Description:  "The aws partition (aws, aws-cn, or aws-us-gov) used to build the resource ARNs. If not set, it is derived from the region.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"aws", "aws-cn", "aws-us-gov"}, false),
+			},
+			"kms_key_arns": {
+				Description: "Add permissions required to read and write S3 objects encrypted with these KMS keys (SSE-KMS).",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ebs_kms_key_arn": {
+				Description: "Add permissions required to attach EBS volumes encrypted with this KMS key.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
 		},
 		ReadContext: dataSourceAWSInstanceProfilePolicyRead,
 	}
@@ -139,7 +163,7 @@ func awsBackupPermissions(s3Resources interface{}) awsPolicyStatement {
 	}
 }
 
-func awsCloudWatchPermissions() []awsPolicyStatement {
+func awsCloudWatchPermissions(partition string) []awsPolicyStatement {
 	return []awsPolicyStatement{
 		{
 			Sid:    "CloudwatchPermissions",
@@ -161,7 +185,7 @@ func awsCloudWatchPermissions() []awsPolicyStatement {
 			Action: []string{
 				"ssm:GetParameter",
 			},
-			Resources: "arn:aws:ssm:*:*:parameter/AmazonCloudWatch-*",
+			Resources: fmt.Sprintf("arn:%s:ssm:*:*:parameter/AmazonCloudWatch-*", partition),
 		},
 	}
 }
@@ -225,13 +249,86 @@ func awsEKSPermissions(allowDescribeEKSResource interface{}) []awsPolicyStatemen
 	}
 }
 
-func dataSourceAWSInstanceProfilePolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+// awsPartitionFromRegion returns the aws partition that the region belongs to.
+func awsPartitionFromRegion(region string) string {
+	if strings.HasPrefix(region, "cn-") {
+		return "aws-cn"
+	} else if strings.HasPrefix(region, "us-gov-") {
+		return "aws-us-gov"
+	}
+	return "aws"
+}
+
+func awsDNSSuffix(partition string) string {
+	if partition == "aws-cn" {
+		return "amazonaws.com.cn"
+	}
+	return "amazonaws.com"
+}
+
+func awsKMSViaServiceCondition(service string, region string, partition string) map[string]map[string]interface{} {
+	return map[string]map[string]interface{}{
+		"StringLike": {
+			"kms:ViaService": fmt.Sprintf("%s.%s.%s", service, region, awsDNSSuffix(partition)),
+		},
+	}
+}
+
+func awsS3KMSPermissions(kmsKeyArns []string, region string, partition string) awsPolicyStatement {
+	return awsPolicyStatement{
+		Sid:    "S3KMSPermissions",
+		Effect: "Allow",
+		Action: []string{
+			"kms:Decrypt",
+			"kms:GenerateDataKey",
+		},
+		Resources: kmsKeyArns,
+		Condition: awsKMSViaServiceCondition("s3", region, partition),
+	}
+}
+
+func awsEBSKMSPermissions(ebsKmsKeyArn string, region string, partition string) []awsPolicyStatement {
+	createGrantCondition := awsKMSViaServiceCondition("ec2", region, partition)
+	createGrantCondition["Bool"] = map[string]interface{}{
+		"kms:GrantIsForAWSResource": true,
+	}
+	return []awsPolicyStatement{
+		{
+			Sid:    "EBSKMSPermissions",
+			Effect: "Allow",
+			Action: []string{
+				"kms:Decrypt",
+				"kms:GenerateDataKey",
+			},
+			Resources: ebsKmsKeyArn,
+			Condition: awsKMSViaServiceCondition("ec2", region, partition),
+		}, {
+			Sid:    "EBSKMSCreateGrant",
+			Effect: "Allow",
+			Action: []string{
+				"kms:CreateGrant",
+			},
+			Resources: ebsKmsKeyArn,
+			Condition: createGrantCondition,
+		},
+	}
+}
+
+// awsInstanceProfilePolicy builds the instance profile policy based on the data source inputs and returns it together with the aws partition.
+func awsInstanceProfilePolicy(d *schema.ResourceData) (awsPolicy, string) {
+	var partition string
+	if v, ok := d.GetOk("partition"); ok {
+		partition = v.(string)
+	} else {
+		partition = awsPartitionFromRegion(d.Get("region").(string))
+	}
+
 	var s3Resources interface{} = "*"
 	if v, ok := d.GetOk("bucket_name"); ok {
 		bucketName := v.(string)
 		s3Resources = []string{
-			fmt.Sprintf("arn:aws:s3:::%s/*", bucketName),
-			fmt.Sprintf("arn:aws:s3:::%s", bucketName),
+			fmt.Sprintf("arn:%s:s3:::%s/*", partition, bucketName),
+			fmt.Sprintf("arn:%s:s3:::%s", partition, bucketName),
 		}
 	}
 
@@ -248,15 +345,32 @@ func dataSourceAWSInstanceProfilePolicyRead(ctx context.Context, d *schema.Resou
 		policy.Statements = append(policy.Statements, awsBackupPermissions(s3Resources))
 	}
 
+	var kmsRegion = "*"
+	if v, ok := d.GetOk("region"); ok {
+		kmsRegion = v.(string)
+	}
+
+	if v, ok := d.GetOk("kms_key_arns"); ok {
+		kmsKeyArns := make([]string, 0)
+		for _, arn := range v.([]interface{}) {
+			kmsKeyArns = append(kmsKeyArns, arn.(string))
+		}
+		policy.Statements = append(policy.Statements, awsS3KMSPermissions(kmsKeyArns, kmsRegion, partition))
+	}
+
+	if v, ok := d.GetOk("ebs_kms_key_arn"); ok {
+		policy.Statements = append(policy.Statements, awsEBSKMSPermissions(v.(string), kmsRegion, partition)...)
+	}
+
 	if d.Get("enable_cloud_watch").(bool) {
-		policy.Statements = append(policy.Statements, awsCloudWatchPermissions()...)
+		policy.Statements = append(policy.Statements, awsCloudWatchPermissions(partition)...)
 	}
 
 	if d.Get("enable_eks_and_ecr").(bool) || d.Get("enable_eks").(bool) {
-		var allowDescribeEKSResource interface{} = "arn:aws:eks:*:*:cluster/*"
+		var allowDescribeEKSResource interface{} = fmt.Sprintf("arn:%s:eks:*:*:cluster/*", partition)
 		if v, ok := d.GetOk("eks_cluster_name"); ok {
 			eksClusterName := v.(string)
-			allowDescribeEKSResource = fmt.Sprintf("arn:aws:eks:*:*:cluster/%s", eksClusterName)
+			allowDescribeEKSResource = fmt.Sprintf("arn:%s:eks:*:*:cluster/%s", partition, eksClusterName)
 		}
 
 		policy.Statements = append(policy.Statements, awsEKSPermissions(allowDescribeEKSResource)...)
@@ -280,25 +394,30 @@ func dataSourceAWSInstanceProfilePolicyRead(ctx context.Context, d *schema.Resou
 			hopsworksaiEcrAccount = v.(string)
 		}
 		var allowPushandPullImagesResource = []string{
-			fmt.Sprintf("arn:aws:ecr:%s:%s:repository/%s/filebeat", region, userEcrAccount, clusterId),
-			fmt.Sprintf("arn:aws:ecr:%s:%s:repository/%s/base", region, userEcrAccount, clusterId),
-			fmt.Sprintf("arn:aws:ecr:%s:%s:repository/%s/onlinefs", region, userEcrAccount, clusterId),
-			fmt.Sprintf("arn:aws:ecr:%s:%s:repository/%s/airflow", region, userEcrAccount, clusterId),
-			fmt.Sprintf("arn:aws:ecr:%s:%s:repository/%s/git", region, userEcrAccount, clusterId),
-			fmt.Sprintf("arn:aws:ecr:%s:%s:repository/%s/testconnector", region, userEcrAccount, clusterId),
-			fmt.Sprintf("arn:aws:ecr:%s:%s:repository/%s/flyingduck", region, userEcrAccount, clusterId),
+			fmt.Sprintf("arn:%s:ecr:%s:%s:repository/%s/filebeat", partition, region, userEcrAccount, clusterId),
+			fmt.Sprintf("arn:%s:ecr:%s:%s:repository/%s/base", partition, region, userEcrAccount, clusterId),
+			fmt.Sprintf("arn:%s:ecr:%s:%s:repository/%s/onlinefs", partition, region, userEcrAccount, clusterId),
+			fmt.Sprintf("arn:%s:ecr:%s:%s:repository/%s/airflow", partition, region, userEcrAccount, clusterId),
+			fmt.Sprintf("arn:%s:ecr:%s:%s:repository/%s/git", partition, region, userEcrAccount, clusterId),
+			fmt.Sprintf("arn:%s:ecr:%s:%s:repository/%s/testconnector", partition, region, userEcrAccount, clusterId),
+			fmt.Sprintf("arn:%s:ecr:%s:%s:repository/%s/flyingduck", partition, region, userEcrAccount, clusterId),
 		}
 		var allowPullImagesFromHopsworkAiResource = []string{
-			fmt.Sprintf("arn:aws:ecr:%s:%s:repository/filebeat", region, hopsworksaiEcrAccount),
-			fmt.Sprintf("arn:aws:ecr:%s:%s:repository/base", region, hopsworksaiEcrAccount),
-			fmt.Sprintf("arn:aws:ecr:%s:%s:repository/onlinefs", region, hopsworksaiEcrAccount),
-			fmt.Sprintf("arn:aws:ecr:%s:%s:repository/airflow", region, hopsworksaiEcrAccount),
-			fmt.Sprintf("arn:aws:ecr:%s:%s:repository/git", region, hopsworksaiEcrAccount),
-			fmt.Sprintf("arn:aws:ecr:%s:%s:repository/testconnector", region, hopsworksaiEcrAccount),
-			fmt.Sprintf("arn:aws:ecr:%s:%s:repository/flyingduck", region, hopsworksaiEcrAccount),
+			fmt.Sprintf("arn:%s:ecr:%s:%s:repository/filebeat", partition, region, hopsworksaiEcrAccount),
+			fmt.Sprintf("arn:%s:ecr:%s:%s:repository/base", partition, region, hopsworksaiEcrAccount),
+			fmt.Sprintf("arn:%s:ecr:%s:%s:repository/onlinefs", partition, region, hopsworksaiEcrAccount),
+			fmt.Sprintf("arn:%s:ecr:%s:%s:repository/airflow", partition, region, hopsworksaiEcrAccount),
+			fmt.Sprintf("arn:%s:ecr:%s:%s:repository/git", partition, region, hopsworksaiEcrAccount),
+			fmt.Sprintf("arn:%s:ecr:%s:%s:repository/testconnector", partition, region, hopsworksaiEcrAccount),
+			fmt.Sprintf("arn:%s:ecr:%s:%s:repository/flyingduck", partition, region, hopsworksaiEcrAccount),
 		}
 		policy.Statements = append(policy.Statements, awsECRPermissions(allowPullImagesFromHopsworkAiResource, allowPushandPullImagesResource)...)
 	}
+	return policy, partition
+}
+
+func dataSourceAWSInstanceProfilePolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	policy, partition := awsInstanceProfilePolicy(d)
 
 	policyJson, err := json.MarshalIndent(policy, "", "  ")
 	if err != nil {
@@ -308,6 +427,9 @@ func dataSourceAWSInstanceProfilePolicyRead(ctx context.Context, d *schema.Resou
 	policyString := string(policyJson)
 
 	d.SetId(strconv.Itoa(schema.HashString(policyString)))
+	if err := d.Set("partition", partition); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := d.Set("json", policyString); err != nil {
 		return diag.FromErr(err)
 	}
@@ -1,10 +1,12 @@
 package hopsworksai
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/test"
 )
 
 func TestAccAWSInstanceProfilePolicy_basic(t *testing.T) {
@@ -16,7 +18,7 @@ func TestAccAWSInstanceProfilePolicy_basic(t *testing.T) {
 			awsBackupPermissions("*"),
 		},
 	}
-	policy.Statements = append(policy.Statements, awsCloudWatchPermissions()...)
+	policy.Statements = append(policy.Statements, awsCloudWatchPermissions("aws")...)
 	var allowDescribeEKSResource interface{} = "arn:aws:eks:*:*:cluster/*"
 	var allowPushandPullImagesResource = []string{
 		"arn:aws:ecr:*:*:repository/*/filebeat",
@@ -61,7 +63,7 @@ func TestAccAWSInstanceProfilePolicy_eks_restriction(t *testing.T) {
 			awsBackupPermissions("*"),
 		},
 	}
-	policy.Statements = append(policy.Statements, awsCloudWatchPermissions()...)
+	policy.Statements = append(policy.Statements, awsCloudWatchPermissions("aws")...)
 	var allowDescribeEKSResource interface{} = "arn:aws:eks:*:*:cluster/cluster_name"
 	var allowPushandPullImagesResource = []string{
 		"arn:aws:ecr:*:*:repository/*/filebeat",
@@ -106,7 +108,7 @@ func TestAccAWSInstanceProfilePolicy_cluster_id(t *testing.T) {
 			awsBackupPermissions("*"),
 		},
 	}
-	policy.Statements = append(policy.Statements, awsCloudWatchPermissions()...)
+	policy.Statements = append(policy.Statements, awsCloudWatchPermissions("aws")...)
 	var allowDescribeEKSResource interface{} = "arn:aws:eks:*:*:cluster/*"
 	var allowPushandPullImagesResource = []string{
 		"arn:aws:ecr:*:*:repository/cluster_id/filebeat",
@@ -151,7 +153,7 @@ func TestAccAWSInstanceProfilePolicy_singleBucket(t *testing.T) {
 			awsBackupPermissions([]string{"arn:aws:s3:::test/*", "arn:aws:s3:::test"}),
 		},
 	}
-	policy.Statements = append(policy.Statements, awsCloudWatchPermissions()...)
+	policy.Statements = append(policy.Statements, awsCloudWatchPermissions("aws")...)
 	var allowDescribeEKSResource interface{} = "arn:aws:eks:*:*:cluster/*"
 	var allowPushandPullImagesResource = []string{
 		"arn:aws:ecr:*:*:repository/*/filebeat",
@@ -196,7 +198,7 @@ func TestAccAWSInstanceProfilePolicy_disableEKSAndECR(t *testing.T) {
 			awsBackupPermissions("*"),
 		},
 	}
-	policy.Statements = append(policy.Statements, awsCloudWatchPermissions()...)
+	policy.Statements = append(policy.Statements, awsCloudWatchPermissions("aws")...)
 
 	resource.UnitTest(t, resource.TestCase{
 		ProviderFactories: testAccProviderFactories,
@@ -220,7 +222,7 @@ func TestAccAWSInstanceProfilePolicy_disableEKS(t *testing.T) {
 			awsBackupPermissions("*"),
 		},
 	}
-	policy.Statements = append(policy.Statements, awsCloudWatchPermissions()...)
+	policy.Statements = append(policy.Statements, awsCloudWatchPermissions("aws")...)
 	var allowPushandPullImagesResource = []string{
 		"arn:aws:ecr:*:*:repository/*/filebeat",
 		"arn:aws:ecr:*:*:repository/*/base",
@@ -263,7 +265,7 @@ func TestAccAWSInstanceProfilePolicy_limitRegion(t *testing.T) {
 			awsBackupPermissions("*"),
 		},
 	}
-	policy.Statements = append(policy.Statements, awsCloudWatchPermissions()...)
+	policy.Statements = append(policy.Statements, awsCloudWatchPermissions("aws")...)
 	var allowPushandPullImagesResource = []string{
 		"arn:aws:ecr:us-east-2:*:repository/*/filebeat",
 		"arn:aws:ecr:us-east-2:*:repository/*/base",
@@ -306,7 +308,7 @@ func TestAccAWSInstanceProfilePolicy_limitUserAccount(t *testing.T) {
 			awsBackupPermissions("*"),
 		},
 	}
-	policy.Statements = append(policy.Statements, awsCloudWatchPermissions()...)
+	policy.Statements = append(policy.Statements, awsCloudWatchPermissions("aws")...)
 	var allowPushandPullImagesResource = []string{
 		"arn:aws:ecr:*:user:repository/*/filebeat",
 		"arn:aws:ecr:*:user:repository/*/base",
@@ -349,7 +351,7 @@ func TestAccAWSInstanceProfilePolicy_limitHopsworksAccount(t *testing.T) {
 			awsBackupPermissions("*"),
 		},
 	}
-	policy.Statements = append(policy.Statements, awsCloudWatchPermissions()...)
+	policy.Statements = append(policy.Statements, awsCloudWatchPermissions("aws")...)
 	var allowPushandPullImagesResource = []string{
 		"arn:aws:ecr:*:*:repository/*/filebeat",
 		"arn:aws:ecr:*:*:repository/*/base",
@@ -405,6 +407,320 @@ func TestAccAWSInstanceProfilePolicy_enableOnlyStorage(t *testing.T) {
 	})
 }
 
+func TestAccAWSInstanceProfilePolicy_kmsKeys(t *testing.T) {
+	dataSourceName := "data.hopsworksai_aws_instance_profile_policy.test"
+	policy := &awsPolicy{
+		Version: "2012-10-17",
+		Statements: []awsPolicyStatement{
+			awsStoragePermissions("*"),
+			awsS3KMSPermissions([]string{"arn:aws:kms:us-east-2:000011112222:key/s3-key"}, "us-east-2", "aws"),
+		},
+	}
+	policy.Statements = append(policy.Statements, awsEBSKMSPermissions("arn:aws:kms:us-east-2:000011112222:key/ebs-key", "us-east-2", "aws")...)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSInstanceProfilePolicyConfig_kmsKeys(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "json", testAccAWSPolicyToJSONString(t, policy)),
+				),
+			},
+		},
+	})
+}
+
+func TestAWSInstanceProfilePolicyRead_s3KMSKeys(t *testing.T) {
+	r := test.ResourceFixture{
+		Resource:             dataSourceAWSInstanceProfilePolicy(),
+		OperationContextFunc: dataSourceAWSInstanceProfilePolicy().ReadContext,
+		State: map[string]interface{}{
+			"enable_backup":      false,
+			"enable_cloud_watch": false,
+			"enable_eks":         false,
+			"enable_ecr":         false,
+			"bucket_name":        "my-bucket",
+			"kms_key_arns": []interface{}{
+				"arn:aws:kms:*:000011112222:key/key-1",
+				"arn:aws:kms:*:000011112222:key/key-2",
+			},
+		},
+		ExpectState: map[string]interface{}{
+			"json": `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "S3Permissions",
+      "Effect": "Allow",
+      "Action": [
+        "S3:PutObject",
+        "S3:ListBucket",
+        "S3:GetObject",
+        "S3:DeleteObject",
+        "S3:AbortMultipartUpload",
+        "S3:ListBucketMultipartUploads",
+        "S3:GetBucketVersioning"
+      ],
+      "Resource": [
+        "arn:aws:s3:::my-bucket/*",
+        "arn:aws:s3:::my-bucket"
+      ]
+    },
+    {
+      "Sid": "S3KMSPermissions",
+      "Effect": "Allow",
+      "Action": [
+        "kms:Decrypt",
+        "kms:GenerateDataKey"
+      ],
+      "Resource": [
+        "arn:aws:kms:*:000011112222:key/key-1",
+        "arn:aws:kms:*:000011112222:key/key-2"
+      ],
+      "Condition": {
+        "StringLike": {
+          "kms:ViaService": "s3.*.amazonaws.com"
+        }
+      }
+    }
+  ]
+}`,
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestAWSInstanceProfilePolicyRead_ebsKMSKey(t *testing.T) {
+	r := test.ResourceFixture{
+		Resource:             dataSourceAWSInstanceProfilePolicy(),
+		OperationContextFunc: dataSourceAWSInstanceProfilePolicy().ReadContext,
+		State: map[string]interface{}{
+			"enable_storage":     false,
+			"enable_backup":      false,
+			"enable_cloud_watch": false,
+			"enable_eks":         false,
+			"enable_ecr":         false,
+			"region":             "us-east-2",
+			"ebs_kms_key_arn":    "arn:aws:kms:us-east-2:000011112222:key/ebs-key",
+		},
+		ExpectState: map[string]interface{}{
+			"json": `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "EBSKMSPermissions",
+      "Effect": "Allow",
+      "Action": [
+        "kms:Decrypt",
+        "kms:GenerateDataKey"
+      ],
+      "Resource": "arn:aws:kms:us-east-2:000011112222:key/ebs-key",
+      "Condition": {
+        "StringLike": {
+          "kms:ViaService": "ec2.us-east-2.amazonaws.com"
+        }
+      }
+    },
+    {
+      "Sid": "EBSKMSCreateGrant",
+      "Effect": "Allow",
+      "Action": [
+        "kms:CreateGrant"
+      ],
+      "Resource": "arn:aws:kms:us-east-2:000011112222:key/ebs-key",
+      "Condition": {
+        "Bool": {
+          "kms:GrantIsForAWSResource": true
+        },
+        "StringLike": {
+          "kms:ViaService": "ec2.us-east-2.amazonaws.com"
+        }
+      }
+    }
+  ]
+}`,
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestAWSInstanceProfilePolicyRead_partitionFromRegion(t *testing.T) {
+	r := test.ResourceFixture{
+		Resource:             dataSourceAWSInstanceProfilePolicy(),
+		OperationContextFunc: dataSourceAWSInstanceProfilePolicy().ReadContext,
+		State: map[string]interface{}{
+			"enable_backup":      false,
+			"enable_cloud_watch": false,
+			"enable_ecr":         false,
+			"bucket_name":        "my-bucket",
+			"region":             "cn-north-1",
+			"eks_cluster_name":   "my-eks",
+			"kms_key_arns": []interface{}{
+				"arn:aws-cn:kms:cn-north-1:000011112222:key/key-1",
+			},
+		},
+		ExpectState: map[string]interface{}{
+			"partition": "aws-cn",
+			"json": `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "S3Permissions",
+      "Effect": "Allow",
+      "Action": [
+        "S3:PutObject",
+        "S3:ListBucket",
+        "S3:GetObject",
+        "S3:DeleteObject",
+        "S3:AbortMultipartUpload",
+        "S3:ListBucketMultipartUploads",
+        "S3:GetBucketVersioning"
+      ],
+      "Resource": [
+        "arn:aws-cn:s3:::my-bucket/*",
+        "arn:aws-cn:s3:::my-bucket"
+      ]
+    },
+    {
+      "Sid": "S3KMSPermissions",
+      "Effect": "Allow",
+      "Action": [
+        "kms:Decrypt",
+        "kms:GenerateDataKey"
+      ],
+      "Resource": [
+        "arn:aws-cn:kms:cn-north-1:000011112222:key/key-1"
+      ],
+      "Condition": {
+        "StringLike": {
+          "kms:ViaService": "s3.cn-north-1.amazonaws.com.cn"
+        }
+      }
+    },
+    {
+      "Sid": "AllowDescribeEKS",
+      "Effect": "Allow",
+      "Action": [
+        "eks:DescribeCluster"
+      ],
+      "Resource": "arn:aws-cn:eks:*:*:cluster/my-eks"
+    }
+  ]
+}`,
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestAWSInstanceProfilePolicyRead_explicitPartition(t *testing.T) {
+	r := test.ResourceFixture{
+		Resource:             dataSourceAWSInstanceProfilePolicy(),
+		OperationContextFunc: dataSourceAWSInstanceProfilePolicy().ReadContext,
+		State: map[string]interface{}{
+			"enable_storage":          false,
+			"enable_backup":           false,
+			"enable_eks":              false,
+			"partition":               "aws-us-gov",
+			"cluster_id":              "cluster-id",
+			"user_ecr_account":        "000011112222",
+			"hopsworksai_ecr_account": "333344445555",
+		},
+		ExpectState: map[string]interface{}{
+			"partition": "aws-us-gov",
+			"json": `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "CloudwatchPermissions",
+      "Effect": "Allow",
+      "Action": [
+        "cloudwatch:PutMetricData",
+        "ec2:DescribeVolumes",
+        "ec2:DescribeTags",
+        "logs:PutLogEvents",
+        "logs:DescribeLogStreams",
+        "logs:DescribeLogGroups",
+        "logs:CreateLogStream",
+        "logs:CreateLogGroup"
+      ],
+      "Resource": "*"
+    },
+    {
+      "Sid": "HopsworksAICloudWatchParam",
+      "Effect": "Allow",
+      "Action": [
+        "ssm:GetParameter"
+      ],
+      "Resource": "arn:aws-us-gov:ssm:*:*:parameter/AmazonCloudWatch-*"
+    },
+    {
+      "Sid": "AllowPullImagesFromHopsworkAi",
+      "Effect": "Allow",
+      "Action": [
+        "ecr:GetDownloadUrlForLayer",
+        "ecr:BatchGetImage"
+      ],
+      "Resource": [
+        "arn:aws-us-gov:ecr:*:333344445555:repository/filebeat",
+        "arn:aws-us-gov:ecr:*:333344445555:repository/base",
+        "arn:aws-us-gov:ecr:*:333344445555:repository/onlinefs",
+        "arn:aws-us-gov:ecr:*:333344445555:repository/airflow",
+        "arn:aws-us-gov:ecr:*:333344445555:repository/git",
+        "arn:aws-us-gov:ecr:*:333344445555:repository/testconnector",
+        "arn:aws-us-gov:ecr:*:333344445555:repository/flyingduck"
+      ]
+    },
+    {
+      "Sid": "AllowCreateRepository",
+      "Effect": "Allow",
+      "Action": [
+        "ecr:CreateRepository"
+      ],
+      "Resource": "*"
+    },
+    {
+      "Sid": "AllowPushandPullImagesToUserRepo",
+      "Effect": "Allow",
+      "Action": [
+        "ecr:GetDownloadUrlForLayer",
+        "ecr:BatchGetImage",
+        "ecr:CompleteLayerUpload",
+        "ecr:UploadLayerPart",
+        "ecr:InitiateLayerUpload",
+        "ecr:BatchCheckLayerAvailability",
+        "ecr:PutImage",
+        "ecr:ListImages",
+        "ecr:BatchDeleteImage",
+        "ecr:GetLifecyclePolicy",
+        "ecr:PutLifecyclePolicy",
+        "ecr:TagResource"
+      ],
+      "Resource": [
+        "arn:aws-us-gov:ecr:*:000011112222:repository/cluster-id/filebeat",
+        "arn:aws-us-gov:ecr:*:000011112222:repository/cluster-id/base",
+        "arn:aws-us-gov:ecr:*:000011112222:repository/cluster-id/onlinefs",
+        "arn:aws-us-gov:ecr:*:000011112222:repository/cluster-id/airflow",
+        "arn:aws-us-gov:ecr:*:000011112222:repository/cluster-id/git",
+        "arn:aws-us-gov:ecr:*:000011112222:repository/cluster-id/testconnector",
+        "arn:aws-us-gov:ecr:*:000011112222:repository/cluster-id/flyingduck"
+      ]
+    },
+    {
+      "Sid": "AllowGetAuthToken",
+      "Effect": "Allow",
+      "Action": [
+        "ecr:GetAuthorizationToken"
+      ],
+      "Resource": "*"
+    }
+  ]
+}`,
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
 func testAccAWSInstanceProfilePolicyConfig_basic() string {
 	return `
 	data "hopsworksai_aws_instance_profile_policy" "test" {
@@ -491,6 +807,20 @@ func testAccAWSInstanceProfilePolicyConfig_limitHopsworksAccount() string {
 	`
 }
 
+func testAccAWSInstanceProfilePolicyConfig_kmsKeys() string {
+	return `
+	data "hopsworksai_aws_instance_profile_policy" "test" {
+		enable_backup = false
+		enable_cloud_watch = false
+		enable_eks = false
+		enable_ecr = false
+		region = "us-east-2"
+		kms_key_arns = ["arn:aws:kms:us-east-2:000011112222:key/s3-key"]
+		ebs_kms_key_arn = "arn:aws:kms:us-east-2:000011112222:key/ebs-key"
+	}
+	`
+}
+
 func testAccAWSPolicyToJSONString(t *testing.T, policy *awsPolicy) string {
 	policyJson, err := json.MarshalIndent(policy, "", "  ")
 	if err != nil {
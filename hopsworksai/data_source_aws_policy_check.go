@@ -0,0 +1,217 @@
+package hopsworksai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/helpers"
+)
+
+// policyCheckInputSchema returns the input attributes of a permissions data source so that
+// the policy check data sources can generate the same required permissions.
+func policyCheckInputSchema(permissionsSchema map[string]*schema.Schema, excludedKeys ...string) map[string]*schema.Schema {
+	inputSchema := make(map[string]*schema.Schema, len(permissionsSchema))
+	for k, v := range permissionsSchema {
+		inputSchema[k] = v
+	}
+	for _, k := range excludedKeys {
+		delete(inputSchema, k)
+	}
+	return inputSchema
+}
+
+func dataSourceAWSPolicyCheck() *schema.Resource {
+	policyCheckSchema := policyCheckInputSchema(dataSourceAWSInstanceProfilePolicy().Schema, "json")
+	policyCheckSchema["policy_json"] = &schema.Schema{
+		Description:  "The policy document in JSON format to check against the permissions needed by Hopsworks.ai.",
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.StringIsJSON,
+	}
+	policyCheckSchema["fail_on_missing"] = &schema.Schema{
+		Description: "Fail if the policy is missing any of the required actions.",
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+	}
+	policyCheckSchema["missing_actions"] = &schema.Schema{
+		Description: "The required actions that are not allowed by the policy on all the required resources.",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+	policyCheckSchema["over_broad_resources"] = &schema.Schema{
+		Description: "The required actions that are allowed by the policy on broader resources than needed, in the format <action> on <resource>.",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+
+	return &schema.Resource{
+		Description: "Use this data source to check whether an aws policy covers the instance profile permissions needed by Hopsworks.ai. The check is done offline and ignores the policy conditions, NotAction, and NotResource elements.",
+		Schema:      policyCheckSchema,
+		ReadContext: dataSourceAWSPolicyCheckRead,
+	}
+}
+
+type awsPolicyDocumentStatement struct {
+	Effect   string      `json:"Effect"`
+	Action   interface{} `json:"Action"`
+	Resource interface{} `json:"Resource"`
+}
+
+func awsPolicyElementToList(element interface{}) []string {
+	switch v := element.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return []string{}
+}
+
+func parseAWSPolicyDocument(policyJson string) ([]awsPolicyDocumentStatement, error) {
+	var document struct {
+		Statement json.RawMessage `json:"Statement"`
+	}
+	if err := json.Unmarshal([]byte(policyJson), &document); err != nil {
+		return nil, err
+	}
+	if len(document.Statement) == 0 {
+		return nil, fmt.Errorf("policy has no statements")
+	}
+
+	var statements []awsPolicyDocumentStatement
+	if err := json.Unmarshal(document.Statement, &statements); err != nil {
+		var statement awsPolicyDocumentStatement
+		if err := json.Unmarshal(document.Statement, &statement); err != nil {
+			return nil, err
+		}
+		statements = []awsPolicyDocumentStatement{statement}
+	}
+	return statements, nil
+}
+
+func awsStatementMatches(statement awsPolicyDocumentStatement, action string, resource string) bool {
+	actionMatched := false
+	for _, pattern := range awsPolicyElementToList(statement.Action) {
+		if helpers.WildcardMatch(strings.ToLower(pattern), strings.ToLower(action)) {
+			actionMatched = true
+			break
+		}
+	}
+	if !actionMatched {
+		return false
+	}
+	for _, pattern := range awsPolicyElementToList(statement.Resource) {
+		if helpers.WildcardMatch(pattern, resource) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkAWSPolicy compares the statements of the policy against the required policy and returns the missing actions
+// and the actions that are allowed on broader resources than required.
+func checkAWSPolicy(required awsPolicy, statements []awsPolicyDocumentStatement) ([]string, []string) {
+	missingActions := make([]string, 0)
+	overBroadResources := make([]string, 0)
+	missingSet := make(map[string]bool)
+	overBroadSet := make(map[string]bool)
+
+	for _, requiredStatement := range required.Statements {
+		requiredResources := awsPolicyElementToList(requiredStatement.Resources)
+		for _, action := range requiredStatement.Action {
+			for _, resource := range requiredResources {
+				allowed, denied := false, false
+				for _, statement := range statements {
+					if awsStatementMatches(statement, action, resource) {
+						if strings.EqualFold(statement.Effect, "Deny") {
+							denied = true
+						} else if strings.EqualFold(statement.Effect, "Allow") {
+							allowed = true
+						}
+					}
+				}
+				if (!allowed || denied) && !missingSet[action] {
+					missingSet[action] = true
+					missingActions = append(missingActions, action)
+				}
+			}
+
+			for _, statement := range statements {
+				if !strings.EqualFold(statement.Effect, "Allow") {
+					continue
+				}
+				for _, resource := range awsPolicyElementToList(statement.Resource) {
+					if !awsStatementMatches(statement, action, resource) {
+						continue
+					}
+					covered := false
+					for _, requiredResource := range requiredResources {
+						if helpers.WildcardMatch(requiredResource, resource) {
+							covered = true
+							break
+						}
+					}
+					key := fmt.Sprintf("%s on %s", action, resource)
+					if !covered && !overBroadSet[key] {
+						overBroadSet[key] = true
+						overBroadResources = append(overBroadResources, key)
+					}
+				}
+			}
+		}
+	}
+	return missingActions, overBroadResources
+}
+
+func dataSourceAWSPolicyCheckRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	policyJson := d.Get("policy_json").(string)
+	statements, err := parseAWSPolicyDocument(policyJson)
+	if err != nil {
+		return diag.Errorf("failed to parse policy_json, error: %s", err)
+	}
+
+	required, partition := awsInstanceProfilePolicy(d)
+	missingActions, overBroadResources := checkAWSPolicy(required, statements)
+
+	if d.Get("fail_on_missing").(bool) && len(missingActions) > 0 {
+		return diag.Errorf("the policy is missing the following required actions: %s", strings.Join(missingActions, ", "))
+	}
+
+	requiredJson, err := json.Marshal(required)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(policyJson + string(requiredJson))))
+	if err := d.Set("partition", partition); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("missing_actions", missingActions); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("over_broad_resources", overBroadResources); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
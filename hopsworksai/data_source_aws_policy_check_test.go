@@ -0,0 +1,149 @@
+package hopsworksai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/test"
+)
+
+func TestAWSPolicyCheckRead_covered(t *testing.T) {
+	r := test.ResourceFixture{
+		Resource:             dataSourceAWSPolicyCheck(),
+		OperationContextFunc: dataSourceAWSPolicyCheck().ReadContext,
+		State: map[string]interface{}{
+			"enable_backup":      false,
+			"enable_cloud_watch": false,
+			"enable_eks":         false,
+			"enable_ecr":         false,
+			"bucket_name":        "my-bucket",
+			"policy_json": `{
+				"Version": "2012-10-17",
+				"Statement": [
+					{
+						"Effect": "Allow",
+						"Action": ["s3:PutObject", "s3:GetObject", "s3:DeleteObject", "s3:ListBucket*", "s3:AbortMultipartUpload"],
+						"Resource": ["arn:aws:s3:::my-bucket", "arn:aws:s3:::my-bucket/*"]
+					},
+					{
+						"Effect": "Allow",
+						"Action": "s3:GetBucketVersioning",
+						"Resource": ["arn:aws:s3:::my-bucket", "arn:aws:s3:::my-bucket/*"]
+					}
+				]
+			}`,
+		},
+		ExpectState: map[string]interface{}{
+			"missing_actions":      []interface{}{},
+			"over_broad_resources": []interface{}{},
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestAWSPolicyCheckRead_missingAndOverBroad(t *testing.T) {
+	r := test.ResourceFixture{
+		Resource:             dataSourceAWSPolicyCheck(),
+		OperationContextFunc: dataSourceAWSPolicyCheck().ReadContext,
+		State: map[string]interface{}{
+			"enable_storage":     false,
+			"enable_cloud_watch": false,
+			"enable_eks":         false,
+			"enable_ecr":         false,
+			"bucket_name":        "my-bucket",
+			"policy_json": `{
+				"Version": "2012-10-17",
+				"Statement": {
+					"Effect": "Allow",
+					"Action": "s3:*",
+					"Resource": "*"
+				}
+			}`,
+		},
+		ExpectState: map[string]interface{}{
+			"missing_actions": []interface{}{},
+			"over_broad_resources": []interface{}{
+				"S3:PutLifecycleConfiguration on *",
+				"S3:GetLifecycleConfiguration on *",
+				"S3:PutBucketVersioning on *",
+				"S3:ListBucketVersions on *",
+				"S3:DeleteObjectVersion on *",
+			},
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestAWSPolicyCheckRead_deny(t *testing.T) {
+	r := test.ResourceFixture{
+		Resource:             dataSourceAWSPolicyCheck(),
+		OperationContextFunc: dataSourceAWSPolicyCheck().ReadContext,
+		State: map[string]interface{}{
+			"enable_storage": false,
+			"enable_backup":  false,
+			"enable_eks":     false,
+			"enable_ecr":     false,
+			"policy_json": `{
+				"Version": "2012-10-17",
+				"Statement": [
+					{
+						"Effect": "Allow",
+						"Action": ["cloudwatch:*", "ec2:Describe*", "logs:*"],
+						"Resource": "*"
+					},
+					{
+						"Effect": "Deny",
+						"Action": "logs:CreateLogGroup",
+						"Resource": "*"
+					}
+				]
+			}`,
+		},
+		ExpectState: map[string]interface{}{
+			"missing_actions": []interface{}{
+				"logs:CreateLogGroup",
+				"ssm:GetParameter",
+			},
+			"over_broad_resources": []interface{}{},
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestAWSPolicyCheckRead_failOnMissing(t *testing.T) {
+	r := test.ResourceFixture{
+		Resource:             dataSourceAWSPolicyCheck(),
+		OperationContextFunc: dataSourceAWSPolicyCheck().ReadContext,
+		State: map[string]interface{}{
+			"enable_backup":      false,
+			"enable_cloud_watch": false,
+			"enable_eks":         false,
+			"enable_ecr":         false,
+			"fail_on_missing":    true,
+			"policy_json": `{
+				"Version": "2012-10-17",
+				"Statement": [
+					{
+						"Effect": "Allow",
+						"Action": ["s3:PutObject", "s3:GetObject", "s3:ListBucket", "s3:DeleteObject", "s3:AbortMultipartUpload", "s3:ListBucketMultipartUploads"],
+						"Resource": "*"
+					}
+				]
+			}`,
+		},
+		ExpectError: "the policy is missing the following required actions: S3:GetBucketVersioning",
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestAWSPolicyCheckRead_invalidPolicy(t *testing.T) {
+	r := test.ResourceFixture{
+		Resource:             dataSourceAWSPolicyCheck(),
+		OperationContextFunc: dataSourceAWSPolicyCheck().ReadContext,
+		State: map[string]interface{}{
+			"policy_json": `{"Version": "2012-10-17"}`,
+		},
+		ExpectError: "failed to parse policy_json, error: policy has no statements",
+	}
+	r.Apply(t, context.TODO())
+}
@@ -0,0 +1,136 @@
+package hopsworksai
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/helpers"
+)
+
+func dataSourceAzurePolicyCheck() *schema.Resource {
+	policyCheckSchema := policyCheckInputSchema(dataSourceAzureUserAssignedIdentityPermissions().Schema,
+		"actions", "not_actions", "data_actions", "not_data_actions", "assignable_scopes", "role_name", "role_definition_json")
+	for _, k := range []string{"actions", "not_actions", "data_actions", "not_data_actions"} {
+		policyCheckSchema[k] = &schema.Schema{
+			Description: "The " + strings.ReplaceAll(k, "_", " ") + " of the role definition to check.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		}
+	}
+	policyCheckSchema["fail_on_missing"] = &schema.Schema{
+		Description: "Fail if the role definition is missing any of the required actions or data actions.",
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+	}
+	policyCheckSchema["missing_actions"] = &schema.Schema{
+		Description: "The required actions that are not allowed by the role definition.",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+	policyCheckSchema["missing_data_actions"] = &schema.Schema{
+		Description: "The required data actions that are not allowed by the role definition.",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+	policyCheckSchema["over_broad_actions"] = &schema.Schema{
+		Description: "The wildcard actions and data actions in the role definition that allow more than the required permissions.",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+
+	return &schema.Resource{
+		Description: "Use this data source to check whether an azure role definition covers the user assigned identity permissions needed by Hopsworks.ai. The check is done offline.",
+		Schema:      policyCheckSchema,
+		ReadContext: dataSourceAzurePolicyCheckRead,
+	}
+}
+
+func azureActionAllowed(action string, allowed []string, notAllowed []string) bool {
+	for _, pattern := range notAllowed {
+		if helpers.WildcardMatch(strings.ToLower(pattern), strings.ToLower(action)) {
+			return false
+		}
+	}
+	for _, pattern := range allowed {
+		if helpers.WildcardMatch(strings.ToLower(pattern), strings.ToLower(action)) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkAzureActions returns the required actions that are not allowed and the wildcard actions that allow more than required.
+func checkAzureActions(required []string, allowed []string, notAllowed []string) ([]string, []string) {
+	missing := make([]string, 0)
+	for _, action := range required {
+		if !azureActionAllowed(action, allowed, notAllowed) {
+			missing = append(missing, action)
+		}
+	}
+
+	overBroad := make([]string, 0)
+	for _, pattern := range allowed {
+		if !strings.ContainsAny(pattern, "*?") {
+			continue
+		}
+		for _, action := range required {
+			if helpers.WildcardMatch(strings.ToLower(pattern), strings.ToLower(action)) {
+				overBroad = append(overBroad, pattern)
+				break
+			}
+		}
+	}
+	return missing, overBroad
+}
+
+func toStringList(list []interface{}) []string {
+	stringList := make([]string, 0, len(list))
+	for _, v := range list {
+		stringList = append(stringList, v.(string))
+	}
+	return stringList
+}
+
+func dataSourceAzurePolicyCheckRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	requiredActions, requiredDataActions := azureUserAssignedIdentityActions(d)
+
+	missingActions, overBroadActions := checkAzureActions(requiredActions,
+		toStringList(d.Get("actions").([]interface{})),
+		toStringList(d.Get("not_actions").([]interface{})))
+	missingDataActions, overBroadDataActions := checkAzureActions(toStringList(requiredDataActions),
+		toStringList(d.Get("data_actions").([]interface{})),
+		toStringList(d.Get("not_data_actions").([]interface{})))
+	overBroadActions = append(overBroadActions, overBroadDataActions...)
+
+	if d.Get("fail_on_missing").(bool) && len(missingActions)+len(missingDataActions) > 0 {
+		return diag.Errorf("the role definition is missing the following required actions: %s", strings.Join(append(missingActions, missingDataActions...), ", "))
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(strings.Join(requiredActions, ",") + strings.Join(toStringList(requiredDataActions), ","))))
+	if err := d.Set("missing_actions", missingActions); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("missing_data_actions", missingDataActions); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("over_broad_actions", overBroadActions); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
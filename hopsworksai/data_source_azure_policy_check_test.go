@@ -0,0 +1,61 @@
+package hopsworksai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/test"
+)
+
+func TestAzurePolicyCheckRead(t *testing.T) {
+	r := test.ResourceFixture{
+		Resource:             dataSourceAzurePolicyCheck(),
+		OperationContextFunc: dataSourceAzurePolicyCheck().ReadContext,
+		State: map[string]interface{}{
+			"enable_aks": false,
+			"enable_acr": false,
+			"actions": []interface{}{
+				"Microsoft.Storage/storageAccounts/blobServices/*",
+				"Microsoft.Storage/storageAccounts/listKeys/action",
+			},
+			"not_actions": []interface{}{
+				"Microsoft.Storage/storageAccounts/blobServices/write",
+			},
+			"data_actions": []interface{}{
+				"Microsoft.Storage/storageAccounts/blobServices/containers/blobs/read",
+				"Microsoft.Storage/storageAccounts/blobServices/containers/blobs/write",
+				"Microsoft.Storage/storageAccounts/blobServices/containers/blobs/delete",
+			},
+		},
+		ExpectState: map[string]interface{}{
+			"missing_actions": []interface{}{
+				"Microsoft.Storage/storageAccounts/blobServices/write",
+			},
+			"missing_data_actions": []interface{}{
+				"Microsoft.Storage/storageAccounts/blobServices/containers/blobs/move/action",
+			},
+			"over_broad_actions": []interface{}{
+				"Microsoft.Storage/storageAccounts/blobServices/*",
+			},
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestAzurePolicyCheckRead_failOnMissing(t *testing.T) {
+	r := test.ResourceFixture{
+		Resource:             dataSourceAzurePolicyCheck(),
+		OperationContextFunc: dataSourceAzurePolicyCheck().ReadContext,
+		State: map[string]interface{}{
+			"enable_storage":  false,
+			"enable_backup":   false,
+			"enable_acr":      false,
+			"fail_on_missing": true,
+			"actions": []interface{}{
+				"Microsoft.ContainerService/managedClusters/read",
+			},
+		},
+		ExpectError: "the role definition is missing the following required actions: Microsoft.ContainerService/managedClusters/listClusterUserCredential/action",
+	}
+	r.Apply(t, context.TODO())
+}
@@ -2,6 +2,8 @@ package hopsworksai
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -47,6 +49,61 @@ func dataSourceAzureUserAssignedIdentityPermissions() *schema.Resource {
 				Default:       true,
 				ConflictsWith: []string{"enable_aks_and_acr"},
 			},
+			"storage_account_id": {
+				Description: "Limit the storage permissions to this storage account resource id.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"storage_container_name": {
+				Description:  "Limit the storage permissions to this container in the storage account.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"storage_account_id"},
+			},
+			"acr_id": {
+				Description: "Limit the ACR permissions to this container registry resource id.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"aks_id": {
+				Description: "Limit the AKS permissions to this kubernetes cluster resource id.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"assignable_scopes": {
+				Description: "The assignable scopes of the role definition. If not set, the scopes are the resource groups of storage_account_id, acr_id, and aks_id.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"role_assignment_scopes": {
+				Description: "The scopes to assign the role to. These are the storage container or storage account, the ACR, and the AKS resource ids if set, otherwise the assignable scopes.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"minimal_permissions": {
+				Description: "Leave out the actions that are only needed to list resources if the permissions are limited to a storage account or an AKS cluster.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"role_name": {
+				Description: "The name of the custom role in the role definition.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "hopsworksai-user-assigned-identity-role",
+			},
+			"role_definition_json": {
+				Description: "The custom role definition in JSON format. It is empty if no assignable scope is set since Azure rejects role definitions without assignable scopes.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 			"actions": {
 				Description: "The actions permissions.",
 				Type:        schema.TypeList,
@@ -86,16 +143,39 @@ func dataSourceAzureUserAssignedIdentityPermissions() *schema.Resource {
 	}
 }
 
-func dataSourceAzureUserAssignedIdentityPermissionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+type azureRolePermissions struct {
+	Actions        []string      `json:"actions"`
+	NotActions     []string      `json:"notActions"`
+	DataActions    []interface{} `json:"dataActions"`
+	NotDataActions []interface{} `json:"notDataActions"`
+}
+
+type azureRoleDefinitionProperties struct {
+	RoleName         string                 `json:"roleName"`
+	Description      string                 `json:"description"`
+	AssignableScopes []string               `json:"assignableScopes"`
+	Permissions      []azureRolePermissions `json:"permissions"`
+}
+
+type azureRoleDefinition struct {
+	Properties azureRoleDefinitionProperties `json:"properties"`
+}
+
+// azureUserAssignedIdentityActions returns the actions and data actions based on the data source inputs.
+func azureUserAssignedIdentityActions(d *schema.ResourceData) ([]string, []interface{}) {
 	actions := []string{}
-	notActions := []string{}
 	dataActions := []interface{}{}
-	notDataActions := []interface{}{}
+
+	minimalPermissions := d.Get("minimal_permissions").(bool)
+	storageAccountId := d.Get("storage_account_id").(string)
+	aksId := d.Get("aks_id").(string)
 
 	if d.Get("enable_storage").(bool) {
 		actions = append(actions, "Microsoft.Storage/storageAccounts/blobServices/containers/write",
-			"Microsoft.Storage/storageAccounts/blobServices/containers/read",
-			"Microsoft.Storage/storageAccounts/blobServices/read")
+			"Microsoft.Storage/storageAccounts/blobServices/containers/read")
+		if !minimalPermissions || storageAccountId == "" {
+			actions = append(actions, "Microsoft.Storage/storageAccounts/blobServices/read")
+		}
 
 		dataActions = append(dataActions, "Microsoft.Storage/storageAccounts/blobServices/containers/blobs/delete",
 			"Microsoft.Storage/storageAccounts/blobServices/containers/blobs/read",
@@ -109,9 +189,10 @@ func dataSourceAzureUserAssignedIdentityPermissionsRead(ctx context.Context, d *
 	}
 
 	if d.Get("enable_aks_and_acr").(bool) || d.Get("enable_aks").(bool) {
-		actions = append(actions, "Microsoft.ContainerService/managedClusters/listClusterUserCredential/action",
-			"Microsoft.ContainerService/managedClusters/read",
-		)
+		actions = append(actions, "Microsoft.ContainerService/managedClusters/listClusterUserCredential/action")
+		if !minimalPermissions || aksId == "" {
+			actions = append(actions, "Microsoft.ContainerService/managedClusters/read")
+		}
 	}
 
 	if d.Get("enable_aks_and_acr").(bool) || d.Get("enable_acr").(bool) {
@@ -120,8 +201,104 @@ func dataSourceAzureUserAssignedIdentityPermissionsRead(ctx context.Context, d *
 			"Microsoft.ContainerRegistry/registries/artifacts/delete",
 		)
 	}
+	return actions, dataActions
+}
+
+// azureResourceGroupScope returns the resource group scope of an azure resource id since custom roles
+// only accept management groups, subscriptions, or resource groups as assignable scopes.
+func azureResourceGroupScope(resourceId string) (string, error) {
+	parts := strings.Split(strings.Trim(resourceId, "/"), "/")
+	if len(parts) < 4 || !strings.EqualFold(parts[0], "subscriptions") || !strings.EqualFold(parts[2], "resourceGroups") || parts[1] == "" || parts[3] == "" {
+		return "", fmt.Errorf("invalid azure resource id %s, expected /subscriptions/<subscription id>/resourceGroups/<resource group>/...", resourceId)
+	}
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", parts[1], parts[3]), nil
+}
 
-	d.SetId(strconv.Itoa(schema.HashString(strings.Join(actions, ","))))
+func dataSourceAzureUserAssignedIdentityPermissionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	actions, dataActions := azureUserAssignedIdentityActions(d)
+	notActions := []string{}
+	notDataActions := []interface{}{}
+
+	storageAccountId := d.Get("storage_account_id").(string)
+	aksId := d.Get("aks_id").(string)
+
+	roleAssignmentScopes := []string{}
+	if storageAccountId != "" {
+		if containerName, ok := d.GetOk("storage_container_name"); ok {
+			roleAssignmentScopes = append(roleAssignmentScopes, fmt.Sprintf("%s/blobServices/default/containers/%s", storageAccountId, containerName.(string)))
+		} else {
+			roleAssignmentScopes = append(roleAssignmentScopes, storageAccountId)
+		}
+	}
+	if v, ok := d.GetOk("acr_id"); ok {
+		roleAssignmentScopes = append(roleAssignmentScopes, v.(string))
+	}
+	if aksId != "" {
+		roleAssignmentScopes = append(roleAssignmentScopes, aksId)
+	}
+
+	assignableScopes := []string{}
+	if v, ok := d.GetOk("assignable_scopes"); ok {
+		for _, scope := range v.([]interface{}) {
+			assignableScopes = append(assignableScopes, scope.(string))
+		}
+	} else {
+		for _, id := range roleAssignmentScopes {
+			scope, err := azureResourceGroupScope(id)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if !contains(assignableScopes, scope) {
+				assignableScopes = append(assignableScopes, scope)
+			}
+		}
+	}
+
+	if len(roleAssignmentScopes) == 0 {
+		roleAssignmentScopes = assignableScopes
+	}
+
+	var diags diag.Diagnostics
+	roleDefinitionString := ""
+	if len(assignableScopes) == 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "role_definition_json is not generated since no assignable scope is set, set assignable_scopes, storage_account_id, acr_id, or aks_id to generate it",
+		})
+	} else {
+		roleDefinition := azureRoleDefinition{
+			Properties: azureRoleDefinitionProperties{
+				RoleName:         d.Get("role_name").(string),
+				Description:      "The permissions needed by the user assigned identity of Hopsworks clusters",
+				AssignableScopes: assignableScopes,
+				Permissions: []azureRolePermissions{
+					{
+						Actions:        actions,
+						NotActions:     notActions,
+						DataActions:    dataActions,
+						NotDataActions: notDataActions,
+					},
+				},
+			},
+		}
+
+		roleDefinitionJson, err := json.MarshalIndent(roleDefinition, "", "  ")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		roleDefinitionString = string(roleDefinitionJson)
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(fmt.Sprintf("%s:%s:%s", strings.Join(actions, ","), strings.Join(assignableScopes, ","), strings.Join(roleAssignmentScopes, ",")))))
+	if err := d.Set("assignable_scopes", assignableScopes); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("role_assignment_scopes", roleAssignmentScopes); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("role_definition_json", roleDefinitionString); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := d.Set("actions", actions); err != nil {
 		return diag.FromErr(err)
 	}
@@ -136,5 +313,5 @@ func dataSourceAzureUserAssignedIdentityPermissionsRead(ctx context.Context, d *
 		return diag.FromErr(err)
 	}
 
-	return nil
+	return diags
 }
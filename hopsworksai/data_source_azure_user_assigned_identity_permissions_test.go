@@ -1,9 +1,12 @@
 package hopsworksai
 
 import (
+	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/test"
 )
 
 func TestAccAzureUserAssignedIdentity_basic(t *testing.T) {
@@ -121,6 +124,211 @@ func TestAccAzureUserAssignedIdentity_disableAll(t *testing.T) {
 	})
 }
 
+func TestAccAzureUserAssignedIdentity_scoped(t *testing.T) {
+	dataSourceName := "data.hopsworksai_azure_user_assigned_identity_permissions.test"
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureUserAssignedIdentityConfig_scoped(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "actions.#", "4"),
+					resource.TestCheckResourceAttr(dataSourceName, "actions.0", "Microsoft.Storage/storageAccounts/blobServices/containers/write"),
+					resource.TestCheckResourceAttr(dataSourceName, "actions.1", "Microsoft.Storage/storageAccounts/blobServices/containers/read"),
+					resource.TestCheckResourceAttr(dataSourceName, "actions.2", "Microsoft.Storage/storageAccounts/blobServices/write"),
+					resource.TestCheckResourceAttr(dataSourceName, "actions.3", "Microsoft.Storage/storageAccounts/listKeys/action"),
+					resource.TestCheckResourceAttr(dataSourceName, "assignable_scopes.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "assignable_scopes.0", "/subscriptions/sub/resourceGroups/rg"),
+					resource.TestCheckResourceAttr(dataSourceName, "role_assignment_scopes.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "role_assignment_scopes.0", "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/account/blobServices/default/containers/container"),
+				),
+			},
+		},
+	})
+}
+
+func TestAzureUserAssignedIdentityRead_roleDefinition(t *testing.T) {
+	r := test.ResourceFixture{
+		Resource:             dataSourceAzureUserAssignedIdentityPermissions(),
+		OperationContextFunc: dataSourceAzureUserAssignedIdentityPermissions().ReadContext,
+		State: map[string]interface{}{
+			"enable_backup":       false,
+			"storage_account_id":  "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/account",
+			"acr_id":              "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerRegistry/registries/registry",
+			"aks_id":              "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/aks",
+			"minimal_permissions": true,
+			"role_name":           "my-role",
+		},
+		ExpectState: map[string]interface{}{
+			"assignable_scopes": []interface{}{
+				"/subscriptions/sub/resourceGroups/rg",
+			},
+			"role_assignment_scopes": []interface{}{
+				"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/account",
+				"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerRegistry/registries/registry",
+				"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/aks",
+			},
+			"role_definition_json": `{
+  "properties": {
+    "roleName": "my-role",
+    "description": "The permissions needed by the user assigned identity of Hopsworks clusters",
+    "assignableScopes": [
+      "/subscriptions/sub/resourceGroups/rg"
+    ],
+    "permissions": [
+      {
+        "actions": [
+          "Microsoft.Storage/storageAccounts/blobServices/containers/write",
+          "Microsoft.Storage/storageAccounts/blobServices/containers/read",
+          "Microsoft.ContainerService/managedClusters/listClusterUserCredential/action",
+          "Microsoft.ContainerRegistry/registries/pull/read",
+          "Microsoft.ContainerRegistry/registries/push/write",
+          "Microsoft.ContainerRegistry/registries/artifacts/delete"
+        ],
+        "notActions": [],
+        "dataActions": [
+          "Microsoft.Storage/storageAccounts/blobServices/containers/blobs/delete",
+          "Microsoft.Storage/storageAccounts/blobServices/containers/blobs/read",
+          "Microsoft.Storage/storageAccounts/blobServices/containers/blobs/move/action",
+          "Microsoft.Storage/storageAccounts/blobServices/containers/blobs/write"
+        ],
+        "notDataActions": []
+      }
+    ]
+  }
+}`,
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestAzureUserAssignedIdentityRead_noAssignableScopes(t *testing.T) {
+	r := test.ResourceFixture{
+		Resource:             dataSourceAzureUserAssignedIdentityPermissions(),
+		OperationContextFunc: dataSourceAzureUserAssignedIdentityPermissions().ReadContext,
+		State: map[string]interface{}{
+			"role_name": "my-role",
+		},
+		ExpectState: map[string]interface{}{
+			"assignable_scopes":      []interface{}{},
+			"role_assignment_scopes": []interface{}{},
+			"role_definition_json":   "",
+		},
+		ExpectWarning: "role_definition_json is not generated since no assignable scope is set, set assignable_scopes, storage_account_id, acr_id, or aks_id to generate it",
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestAzureUserAssignedIdentityRead_assignableScopes(t *testing.T) {
+	r := test.ResourceFixture{
+		Resource:             dataSourceAzureUserAssignedIdentityPermissions(),
+		OperationContextFunc: dataSourceAzureUserAssignedIdentityPermissions().ReadContext,
+		State: map[string]interface{}{
+			"enable_storage":    false,
+			"enable_backup":     false,
+			"enable_acr":        false,
+			"aks_id":            "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/aks",
+			"assignable_scopes": []interface{}{"/subscriptions/sub"},
+		},
+		ExpectState: map[string]interface{}{
+			"actions": []interface{}{
+				"Microsoft.ContainerService/managedClusters/listClusterUserCredential/action",
+				"Microsoft.ContainerService/managedClusters/read",
+			},
+			"assignable_scopes": []interface{}{
+				"/subscriptions/sub",
+			},
+			"role_assignment_scopes": []interface{}{
+				"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/aks",
+			},
+			"role_definition_json": `{
+  "properties": {
+    "roleName": "hopsworksai-user-assigned-identity-role",
+    "description": "The permissions needed by the user assigned identity of Hopsworks clusters",
+    "assignableScopes": [
+      "/subscriptions/sub"
+    ],
+    "permissions": [
+      {
+        "actions": [
+          "Microsoft.ContainerService/managedClusters/listClusterUserCredential/action",
+          "Microsoft.ContainerService/managedClusters/read"
+        ],
+        "notActions": [],
+        "dataActions": [],
+        "notDataActions": []
+      }
+    ]
+  }
+}`,
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestAzureUserAssignedIdentityRead_multipleResourceGroups(t *testing.T) {
+	r := test.ResourceFixture{
+		Resource:             dataSourceAzureUserAssignedIdentityPermissions(),
+		OperationContextFunc: dataSourceAzureUserAssignedIdentityPermissions().ReadContext,
+		State: map[string]interface{}{
+			"storage_account_id":     "/subscriptions/sub/resourceGroups/storage-rg/providers/Microsoft.Storage/storageAccounts/account",
+			"storage_container_name": "container",
+			"acr_id":                 "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerRegistry/registries/registry",
+			"aks_id":                 "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/aks",
+		},
+		ExpectState: map[string]interface{}{
+			"assignable_scopes": []interface{}{
+				"/subscriptions/sub/resourceGroups/storage-rg",
+				"/subscriptions/sub/resourceGroups/rg",
+			},
+			"role_assignment_scopes": []interface{}{
+				"/subscriptions/sub/resourceGroups/storage-rg/providers/Microsoft.Storage/storageAccounts/account/blobServices/default/containers/container",
+				"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerRegistry/registries/registry",
+				"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/aks",
+			},
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestAzureUserAssignedIdentityRead_invalidResourceId(t *testing.T) {
+	r := test.ResourceFixture{
+		Resource:             dataSourceAzureUserAssignedIdentityPermissions(),
+		OperationContextFunc: dataSourceAzureUserAssignedIdentityPermissions().ReadContext,
+		State: map[string]interface{}{
+			"acr_id": "registry",
+		},
+		ExpectError: "invalid azure resource id registry, expected /subscriptions/<subscription id>/resourceGroups/<resource group>/...",
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestAzureUserAssignedIdentityRead_id(t *testing.T) {
+	read := func(state map[string]interface{}) string {
+		d := schema.TestResourceDataRaw(t, dataSourceAzureUserAssignedIdentityPermissions().Schema, state)
+		if diags := dataSourceAzureUserAssignedIdentityPermissionsRead(context.TODO(), d, nil); diags.HasError() {
+			t.Fatalf("unexpected error: %#v", diags)
+		}
+		return d.Id()
+	}
+
+	aksId := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/aks"
+	id := read(map[string]interface{}{
+		"aks_id": aksId,
+	})
+	if otherId := read(map[string]interface{}{
+		"aks_id":            aksId,
+		"assignable_scopes": []interface{}{"/subscriptions/sub"},
+	}); otherId == id {
+		t.Fatalf("expected a different id when the assignable scopes change, got %s", id)
+	}
+	if otherId := read(map[string]interface{}{
+		"aks_id": "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/other-aks",
+	}); otherId == id {
+		t.Fatalf("expected a different id when the role assignment scopes change, got %s", id)
+	}
+}
+
 func testAccAzureUserAssignedIdentityConfig_basic() string {
 	return `
 	data "hopsworksai_azure_user_assigned_identity_permissions" "test" {
@@ -167,3 +375,15 @@ func testAccAzureUserAssignedIdentityConfig_enableACROnly() string {
 	}
 	`
 }
+
+func testAccAzureUserAssignedIdentityConfig_scoped() string {
+	return `
+	data "hopsworksai_azure_user_assigned_identity_permissions" "test" {
+		enable_aks = false
+		enable_acr = false
+		minimal_permissions = true
+		storage_account_id = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/account"
+		storage_container_name = "container"
+	}
+	`
+}
@@ -2,8 +2,9 @@ package hopsworksai
 
 import (
 	"context"
+	"sort"
 	"strconv"
-	"time"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -43,7 +44,18 @@ func dataSourceBackupsRead(ctx context.Context, d *schema.ResourceData, meta int
 		return diag.FromErr(err)
 	}
 
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	sort.SliceStable(backupsArr, func(i, j int) bool {
+		if backupsArr[i].CreatedOn != backupsArr[j].CreatedOn {
+			return backupsArr[i].CreatedOn > backupsArr[j].CreatedOn
+		}
+		return backupsArr[i].Id < backupsArr[j].Id
+	})
+	backupIds := make([]string, len(backupsArr))
+	for i := range backupsArr {
+		backupIds[i] = backupsArr[i].Id
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(clusterId + ":" + strings.Join(backupIds, ","))))
 	backups := structure.FlattenBackups(backupsArr)
 	if err := d.Set("backups", backups); err != nil {
 		return diag.Errorf("data passed %s, err: %s", backups, err)
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/api"
 	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/test"
@@ -125,17 +127,9 @@ func TestBackupsDataSourceRead(t *testing.T) {
 		State: map[string]interface{}{
 			"cluster_id": "cluster-id-1",
 		},
+		ExpectId: strconv.Itoa(schema.HashString("cluster-id-1:backup-id-2,backup-id-1")),
 		ExpectState: map[string]interface{}{
 			"backups": []interface{}{
-				map[string]interface{}{
-					"backup_id":      "backup-id-1",
-					"backup_name":    "backup-name",
-					"cluster_id":     "cluster-id-1",
-					"cloud_provider": api.AWS.String(),
-					"creation_date":  time.Unix(100, 0).Format(time.RFC3339),
-					"state":          api.BackupSucceed.String(),
-					"state_message":  "message",
-				},
 				map[string]interface{}{
 					"backup_id":      "backup-id-2",
 					"backup_name":    "backup-name-2",
@@ -145,6 +139,15 @@ func TestBackupsDataSourceRead(t *testing.T) {
 					"state":          api.BackupFailed.String(),
 					"state_message":  "failure message",
 				},
+				map[string]interface{}{
+					"backup_id":      "backup-id-1",
+					"backup_name":    "backup-name",
+					"cluster_id":     "cluster-id-1",
+					"cloud_provider": api.AWS.String(),
+					"creation_date":  time.Unix(100, 0).Format(time.RFC3339),
+					"state":          api.BackupSucceed.String(),
+					"state_message":  "message",
+				},
 			},
 		},
 	}
@@ -2,6 +2,9 @@ package hopsworksai
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -12,8 +15,14 @@ import (
 
 func dataSourceCluster() *schema.Resource {
 	clusterDataSchema := helpers.GetDataSourceSchemaFromResourceSchema(clusterSchema())
-	clusterDataSchema["cluster_id"].Computed = false
-	clusterDataSchema["cluster_id"].Required = true
+	lookupKeys := []string{"cluster_id", "name", "tags"}
+	for _, k := range lookupKeys {
+		clusterDataSchema[k].Optional = true
+		clusterDataSchema[k].ExactlyOneOf = lookupKeys
+	}
+	clusterDataSchema["cluster_id"].Description = "The Id of the cluster. Either cluster_id, name, or tags must be set."
+	clusterDataSchema["name"].Description = "The name of the cluster. Either cluster_id, name, or tags must be set."
+	clusterDataSchema["tags"].Description = "The tags of the cluster, the cluster should have all the specified tags. Either cluster_id, name, or tags must be set."
 
 	return &schema.Resource{
 		Description: "Use this data source to get information about a cluster on Hopsworks.ai.",
@@ -22,21 +31,92 @@ func dataSourceCluster() *schema.Resource {
 	}
 }
 
+func clusterHasTags(cluster *api.Cluster, tags map[string]interface{}) bool {
+	clusterTags := make(map[string]string, len(cluster.Tags))
+	for _, tag := range cluster.Tags {
+		clusterTags[tag.Name] = tag.Value
+	}
+	for k, v := range tags {
+		if value, ok := clusterTags[k]; !ok || value != v.(string) {
+			return false
+		}
+	}
+	return true
+}
+
+func filterClusters(clusters []api.Cluster, name string, tags map[string]interface{}) []api.Cluster {
+	filtered := make([]api.Cluster, 0)
+	for i := range clusters {
+		if name != "" && clusters[i].Name != name {
+			continue
+		}
+		if !clusterHasTags(&clusters[i], tags) {
+			continue
+		}
+		filtered = append(filtered, clusters[i])
+	}
+	return filtered
+}
+
+func formatTags(tags map[string]interface{}) string {
+	formatted := make([]string, 0, len(tags))
+	for k, v := range tags {
+		formatted = append(formatted, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(formatted)
+	return strings.Join(formatted, ", ")
+}
+
+func dataSourceClusterLookup(ctx context.Context, client *api.HopsworksAIClient, d *schema.ResourceData) (*api.Cluster, error) {
+	if v, ok := d.GetOk("cluster_id"); ok {
+		clusterId := v.(string)
+		cluster, err := api.GetCluster(ctx, client, clusterId)
+		if err != nil {
+			return nil, err
+		}
+		if cluster == nil {
+			return nil, fmt.Errorf("cluster not found for cluster_id %s", clusterId)
+		}
+		return cluster, nil
+	}
+
+	name := d.Get("name").(string)
+	tags := d.Get("tags").(map[string]interface{})
+	var lookup string
+	if name != "" {
+		lookup = fmt.Sprintf("name %s", name)
+	} else {
+		lookup = fmt.Sprintf("tags %s", formatTags(tags))
+	}
+
+	clusters, err := api.GetClusters(ctx, client, "")
+	if err != nil {
+		return nil, err
+	}
+	matches := filterClusters(clusters, name, tags)
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("cluster not found for %s", lookup)
+	}
+	if len(matches) > 1 {
+		ids := make([]string, len(matches))
+		for i, c := range matches {
+			ids[i] = c.Id
+		}
+		return nil, fmt.Errorf("found %d clusters for %s (%s), use cluster_id instead", len(matches), lookup, strings.Join(ids, ", "))
+	}
+	return &matches[0], nil
+}
+
 func dataSourceClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	client := meta.(*api.HopsworksAIClient)
 
-	clusterId := d.Get("cluster_id").(string)
-	cluster, err := api.GetCluster(ctx, client, clusterId)
+	cluster, err := dataSourceClusterLookup(ctx, client, d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	if cluster == nil {
-		return diag.Errorf("cluster not found for cluster_id %s", clusterId)
-	}
-
-	d.SetId(clusterId)
+	d.SetId(cluster.Id)
 	for k, v := range structure.FlattenCluster(cluster) {
 		if err := d.Set(k, v); err != nil {
 			return diag.FromErr(err)
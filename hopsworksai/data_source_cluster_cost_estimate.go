@@ -0,0 +1,363 @@
+package hopsworksai
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/api"
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/helpers"
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/structure"
+)
+
+const defaultHoursPerMonth = 730
+
+func costEstimateNodeGroupSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"node_type": {
+				Description: "The node type of the node group.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"instance_type": {
+				Description: "The instance type of the node group.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"autoscale": {
+				Description: "The node group is managed by auto scaling.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"spot": {
+				Description: "The node group uses spot instances.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"min_count": {
+				Description: "The minimum number of nodes in the node group.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"max_count": {
+				Description: "The maximum number of nodes in the node group.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"on_demand_hourly_price": {
+				Description: "The hourly on-demand price of one node.",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+			"spot_max_hourly_price": {
+				Description: "The maximum hourly spot price of one node, calculated using max_price_percent. It is 0 if the node group does not use spot instances.",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+			"min_hourly_cost": {
+				Description: "The estimated hourly cost of the node group running min_count nodes.",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+			"max_hourly_cost": {
+				Description: "The estimated hourly cost of the node group running max_count nodes.",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+			"min_monthly_cost": {
+				Description: "The estimated monthly cost of the node group running min_count nodes.",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+			"max_monthly_cost": {
+				Description: "The estimated monthly cost of the node group running max_count nodes.",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceClusterCostEstimate() *schema.Resource {
+	resourceSchema := clusterSchema()
+	dataSourceSchema := helpers.GetDataSourceInputSchemaFromResourceSchema(map[string]*schema.Schema{
+		"head":      resourceSchema["head"],
+		"workers":   resourceSchema["workers"],
+		"rondb":     resourceSchema["rondb"],
+		"autoscale": resourceSchema["autoscale"],
+	})
+
+	dataSourceSchema["cloud_provider"] = &schema.Schema{
+		Description:  "The cloud provider where you plan to create your cluster.",
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.StringInSlice([]string{api.AWS.String(), api.AZURE.String(), api.GCP.String()}, false),
+	}
+	dataSourceSchema["region"] = &schema.Schema{
+		Description: "The region/location/zone where you plan to create your cluster. In case of GCP you should use the zone name.",
+		Type:        schema.TypeString,
+		Required:    true,
+	}
+	dataSourceSchema["price_table"] = &schema.Schema{
+		Description: "The hourly on-demand prices keyed by the instance type Id. These prices take precedence over the prices reported by hopsworks.ai, and they are required for the instance types without a reported price or if offline is set to true, the estimate fails if any instance type has no price. The prices must be greater than 0. You can keep the price table in a JSON file and load it using jsondecode(file(...)) to refresh it independently from the provider.",
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Elem: &schema.Schema{
+			Type: schema.TypeFloat,
+		},
+	}
+	dataSourceSchema["offline"] = &schema.Schema{
+		Description: "Estimate the cost using only price_table without retrieving the prices from hopsworks.ai.",
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+	}
+	dataSourceSchema["hours_per_month"] = &schema.Schema{
+		Description:  "The number of hours per month used to calculate the monthly estimates.",
+		Type:         schema.TypeFloat,
+		Optional:     true,
+		Default:      defaultHoursPerMonth,
+		ValidateFunc: validation.FloatAtLeast(1),
+	}
+	dataSourceSchema["node_groups"] = &schema.Schema{
+		Description: "The cost estimate of every node group in the cluster.",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem:        costEstimateNodeGroupSchema(),
+	}
+	dataSourceSchema["min_hourly_cost"] = &schema.Schema{
+		Description: "The estimated hourly cost of the cluster with auto scaling running the minimum number of workers.",
+		Type:        schema.TypeFloat,
+		Computed:    true,
+	}
+	dataSourceSchema["max_hourly_cost"] = &schema.Schema{
+		Description: "The estimated hourly cost of the cluster with auto scaling running the maximum number of workers.",
+		Type:        schema.TypeFloat,
+		Computed:    true,
+	}
+	dataSourceSchema["min_monthly_cost"] = &schema.Schema{
+		Description: "The estimated monthly cost of the cluster with auto scaling running the minimum number of workers.",
+		Type:        schema.TypeFloat,
+		Computed:    true,
+	}
+	dataSourceSchema["max_monthly_cost"] = &schema.Schema{
+		Description: "The estimated monthly cost of the cluster with auto scaling running the maximum number of workers.",
+		Type:        schema.TypeFloat,
+		Computed:    true,
+	}
+
+	return &schema.Resource{
+		Description: "Use this data source to estimate the cost of a cluster configuration before creating it. The estimate covers only the compute instances, spot instances are estimated using their maximum spot price, and the monthly estimates assume that the cluster runs all the time.",
+		Schema:      dataSourceSchema,
+		ReadContext: dataSourceClusterCostEstimateRead,
+	}
+}
+
+type costEstimateNodeGroup struct {
+	nodeType     api.NodeType
+	instanceType string
+	autoscale    bool
+	minCount     int
+	maxCount     int
+	spotInfo     *api.SpotConfiguration
+}
+
+func expandCostEstimateNodeGroups(d *schema.ResourceData) []costEstimateNodeGroup {
+	groups := []costEstimateNodeGroup{
+		{
+			nodeType:     api.HeadNode,
+			instanceType: d.Get("head.0.instance_type").(string),
+			minCount:     1,
+			maxCount:     1,
+		},
+	}
+
+	if v, ok := d.GetOk("workers"); ok {
+		workers := v.(*schema.Set).List()
+		sort.SliceStable(workers, func(i, j int) bool {
+			return helpers.WorkerKey(workers[i]) < helpers.WorkerKey(workers[j])
+		})
+		for _, w := range workers {
+			worker := structure.ExpandWorker(w.(map[string]interface{}))
+			groups = append(groups, costEstimateNodeGroup{
+				nodeType:     api.WorkerNode,
+				instanceType: worker.InstanceType,
+				minCount:     worker.Count,
+				maxCount:     worker.Count,
+				spotInfo:     worker.SpotInfo,
+			})
+		}
+	}
+
+	if v, ok := d.GetOk("autoscale"); ok {
+		autoscale := structure.ExpandAutoscaleConfiguration(v.([]interface{}))
+		if autoscale.NonGPU != nil {
+			groups = append(groups, costEstimateNodeGroup{
+				nodeType:     api.WorkerNode,
+				instanceType: autoscale.NonGPU.InstanceType,
+				autoscale:    true,
+				minCount:     autoscale.NonGPU.MinWorkers,
+				maxCount:     autoscale.NonGPU.MaxWorkers,
+				spotInfo:     autoscale.NonGPU.SpotInfo,
+			})
+		}
+	}
+
+	if v, ok := d.GetOk("rondb.0.single_node"); ok {
+		node := v.([]interface{})[0].(map[string]interface{})
+		groups = append(groups, costEstimateNodeGroup{
+			nodeType:     api.RonDBDataNode,
+			instanceType: node["instance_type"].(string),
+			minCount:     1,
+			maxCount:     1,
+		})
+	} else {
+		for _, n := range []struct {
+			key      string
+			nodeType api.NodeType
+		}{
+			{key: "management_nodes", nodeType: api.RonDBManagementNode},
+			{key: "data_nodes", nodeType: api.RonDBDataNode},
+			{key: "mysql_nodes", nodeType: api.RonDBMySQLNode},
+			{key: "api_nodes", nodeType: api.RonDBAPINode},
+		} {
+			v, ok := d.GetOk("rondb.0." + n.key)
+			if !ok || len(v.([]interface{})) == 0 || v.([]interface{})[0] == nil {
+				continue
+			}
+			node := v.([]interface{})[0].(map[string]interface{})
+			count := node["count"].(int)
+			if count == 0 {
+				continue
+			}
+			groups = append(groups, costEstimateNodeGroup{
+				nodeType:     n.nodeType,
+				instanceType: node["instance_type"].(string),
+				minCount:     count,
+				maxCount:     count,
+			})
+		}
+	}
+	return groups
+}
+
+func getInstanceTypePrices(supportedTypes *api.SupportedInstanceTypes) map[string]float64 {
+	prices := make(map[string]float64)
+	for _, nodeType := range api.GetAllNodeTypes() {
+		for _, v := range supportedTypes.GetByNodeType(api.NodeType(nodeType)) {
+			if v.Price > 0 {
+				prices[v.Id] = v.Price
+			}
+		}
+	}
+	return prices
+}
+
+func roundCost(cost float64) float64 {
+	return math.Round(cost*10000) / 10000
+}
+
+func estimateNodeGroupCost(group costEstimateNodeGroup, onDemandPrice float64, hoursPerMonth float64) map[string]interface{} {
+	minUnitPrice := onDemandPrice
+	maxUnitPrice := onDemandPrice
+	spotMaxPrice := 0.0
+	if group.spotInfo != nil {
+		spotMaxPrice = onDemandPrice * float64(group.spotInfo.MaxPrice) / 100
+		minUnitPrice = spotMaxPrice
+		maxUnitPrice = spotMaxPrice
+		if group.spotInfo.FallBackOnDemand && onDemandPrice > spotMaxPrice {
+			maxUnitPrice = onDemandPrice
+		}
+	}
+
+	minHourlyCost := float64(group.minCount) * minUnitPrice
+	maxHourlyCost := float64(group.maxCount) * maxUnitPrice
+	return map[string]interface{}{
+		"node_type":              group.nodeType.String(),
+		"instance_type":          group.instanceType,
+		"autoscale":              group.autoscale,
+		"spot":                   group.spotInfo != nil,
+		"min_count":              group.minCount,
+		"max_count":              group.maxCount,
+		"on_demand_hourly_price": onDemandPrice,
+		"spot_max_hourly_price":  roundCost(spotMaxPrice),
+		"min_hourly_cost":        roundCost(minHourlyCost),
+		"max_hourly_cost":        roundCost(maxHourlyCost),
+		"min_monthly_cost":       roundCost(minHourlyCost * hoursPerMonth),
+		"max_monthly_cost":       roundCost(maxHourlyCost * hoursPerMonth),
+	}
+}
+
+func dataSourceClusterCostEstimateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cloud := api.CloudProvider(d.Get("cloud_provider").(string))
+	region := d.Get("region").(string)
+
+	prices := make(map[string]float64)
+	if !d.Get("offline").(bool) {
+		client := meta.(*api.HopsworksAIClient)
+		supportedTypes, err := api.GetSupportedInstanceTypes(ctx, client, cloud, region)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		prices = getInstanceTypePrices(supportedTypes)
+	}
+	for k, v := range d.Get("price_table").(map[string]interface{}) {
+		price := v.(float64)
+		if price <= 0 {
+			return diag.Errorf("invalid price %v for instance type %s in price_table, the price must be greater than 0", price, k)
+		}
+		prices[k] = price
+	}
+
+	hoursPerMonth := d.Get("hours_per_month").(float64)
+	groups := expandCostEstimateNodeGroups(d)
+
+	var missingPrices []string
+	for _, group := range groups {
+		if price, ok := prices[group.instanceType]; (!ok || price <= 0) && !contains(missingPrices, group.instanceType) {
+			missingPrices = append(missingPrices, group.instanceType)
+		}
+	}
+	if len(missingPrices) == 1 {
+		return diag.Errorf("no price available for instance type %s, you can add its hourly price to price_table", missingPrices[0])
+	} else if len(missingPrices) > 1 {
+		return diag.Errorf("no price available for instance types %s, you can add their hourly prices to price_table", strings.Join(missingPrices, ", "))
+	}
+
+	nodeGroups := make([]map[string]interface{}, 0, len(groups))
+	var minHourlyCost, maxHourlyCost float64
+	var idParts []string
+	for _, group := range groups {
+		price := prices[group.instanceType]
+		estimate := estimateNodeGroupCost(group, price, hoursPerMonth)
+		minHourlyCost += estimate["min_hourly_cost"].(float64)
+		maxHourlyCost += estimate["max_hourly_cost"].(float64)
+		nodeGroups = append(nodeGroups, estimate)
+		idParts = append(idParts, fmt.Sprintf("%s-%s-%d-%d-%v", group.nodeType, group.instanceType, group.minCount, group.maxCount, price))
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(fmt.Sprintf("%s:%s:%v:%s", cloud, region, hoursPerMonth, strings.Join(idParts, ",")))))
+	if err := d.Set("node_groups", nodeGroups); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("min_hourly_cost", roundCost(minHourlyCost)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("max_hourly_cost", roundCost(maxHourlyCost)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("min_monthly_cost", roundCost(minHourlyCost*hoursPerMonth)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("max_monthly_cost", roundCost(maxHourlyCost*hoursPerMonth)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
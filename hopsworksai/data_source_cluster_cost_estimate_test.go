@@ -0,0 +1,547 @@
+package hopsworksai
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/api"
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/test"
+)
+
+func costEstimateSupportedTypesOperation() test.Operation {
+	return test.Operation{
+		Method: http.MethodGet,
+		Path:   "/api/clusters/nodes/supported-types",
+		Response: `{
+			"apiVersion": "v1",
+			"status": "ok",
+			"code": 200,
+			"payload": {
+				"aws": {
+					"head": [
+						{
+							"id": "m5.2xlarge",
+							"memory": 32,
+							"cpus": 8,
+							"price": 0.4
+						}
+					],
+					"worker": [
+						{
+							"id": "m5.xlarge",
+							"memory": 16,
+							"cpus": 4,
+							"price": 0.2
+						},
+						{
+							"id": "r5.xlarge",
+							"memory": 32,
+							"cpus": 4,
+							"price": 0.25
+						},
+						{
+							"id": "m5.4xlarge",
+							"memory": 64,
+							"cpus": 16
+						}
+					],
+					"ronDB": {
+						"mgmd": [
+							{
+								"id": "t3a.medium",
+								"memory": 4,
+								"cpus": 2,
+								"price": 0.04
+							}
+						],
+						"ndbd": [
+							{
+								"id": "r5.xlarge",
+								"memory": 32,
+								"cpus": 4,
+								"price": 0.25
+							}
+						],
+						"mysqld": [
+							{
+								"id": "c5.xlarge",
+								"memory": 8,
+								"cpus": 4,
+								"price": 0.17
+							}
+						],
+						"api": []
+					}
+				}
+			}
+		}`,
+	}
+}
+
+func TestClusterCostEstimateDataSourceRead_workers(t *testing.T) {
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			costEstimateSupportedTypesOperation(),
+		},
+		Resource:             dataSourceClusterCostEstimate(),
+		OperationContextFunc: dataSourceClusterCostEstimate().ReadContext,
+		State: map[string]interface{}{
+			"cloud_provider": api.AWS.String(),
+			"region":         "us-east-2",
+			"head": []interface{}{
+				map[string]interface{}{
+					"instance_type": "m5.2xlarge",
+				},
+			},
+			"workers": []interface{}{
+				map[string]interface{}{
+					"instance_type": "r5.xlarge",
+					"count":         1,
+					"spot_config": []interface{}{
+						map[string]interface{}{
+							"max_price_percent":   50,
+							"fall_back_on_demand": true,
+						},
+					},
+				},
+				map[string]interface{}{
+					"instance_type": "m5.xlarge",
+					"count":         2,
+				},
+			},
+			"rondb": []interface{}{
+				map[string]interface{}{
+					"management_nodes": []interface{}{
+						map[string]interface{}{
+							"instance_type": "t3a.medium",
+						},
+					},
+					"data_nodes": []interface{}{
+						map[string]interface{}{
+							"instance_type": "r5.xlarge",
+						},
+					},
+					"mysql_nodes": []interface{}{
+						map[string]interface{}{
+							"instance_type": "c5.xlarge",
+						},
+					},
+				},
+			},
+		},
+		ExpectState: map[string]interface{}{
+			"node_groups": []interface{}{
+				map[string]interface{}{
+					"node_type":              api.HeadNode.String(),
+					"instance_type":          "m5.2xlarge",
+					"autoscale":              false,
+					"spot":                   false,
+					"min_count":              1,
+					"max_count":              1,
+					"on_demand_hourly_price": 0.4,
+					"spot_max_hourly_price":  0.0,
+					"min_hourly_cost":        0.4,
+					"max_hourly_cost":        0.4,
+					"min_monthly_cost":       292.0,
+					"max_monthly_cost":       292.0,
+				},
+				map[string]interface{}{
+					"node_type":              api.WorkerNode.String(),
+					"instance_type":          "m5.xlarge",
+					"autoscale":              false,
+					"spot":                   false,
+					"min_count":              2,
+					"max_count":              2,
+					"on_demand_hourly_price": 0.2,
+					"spot_max_hourly_price":  0.0,
+					"min_hourly_cost":        0.4,
+					"max_hourly_cost":        0.4,
+					"min_monthly_cost":       292.0,
+					"max_monthly_cost":       292.0,
+				},
+				map[string]interface{}{
+					"node_type":              api.WorkerNode.String(),
+					"instance_type":          "r5.xlarge",
+					"autoscale":              false,
+					"spot":                   true,
+					"min_count":              1,
+					"max_count":              1,
+					"on_demand_hourly_price": 0.25,
+					"spot_max_hourly_price":  0.125,
+					"min_hourly_cost":        0.125,
+					"max_hourly_cost":        0.25,
+					"min_monthly_cost":       91.25,
+					"max_monthly_cost":       182.5,
+				},
+				map[string]interface{}{
+					"node_type":              api.RonDBManagementNode.String(),
+					"instance_type":          "t3a.medium",
+					"autoscale":              false,
+					"spot":                   false,
+					"min_count":              1,
+					"max_count":              1,
+					"on_demand_hourly_price": 0.04,
+					"spot_max_hourly_price":  0.0,
+					"min_hourly_cost":        0.04,
+					"max_hourly_cost":        0.04,
+					"min_monthly_cost":       29.2,
+					"max_monthly_cost":       29.2,
+				},
+				map[string]interface{}{
+					"node_type":              api.RonDBDataNode.String(),
+					"instance_type":          "r5.xlarge",
+					"autoscale":              false,
+					"spot":                   false,
+					"min_count":              2,
+					"max_count":              2,
+					"on_demand_hourly_price": 0.25,
+					"spot_max_hourly_price":  0.0,
+					"min_hourly_cost":        0.5,
+					"max_hourly_cost":        0.5,
+					"min_monthly_cost":       365.0,
+					"max_monthly_cost":       365.0,
+				},
+				map[string]interface{}{
+					"node_type":              api.RonDBMySQLNode.String(),
+					"instance_type":          "c5.xlarge",
+					"autoscale":              false,
+					"spot":                   false,
+					"min_count":              1,
+					"max_count":              1,
+					"on_demand_hourly_price": 0.17,
+					"spot_max_hourly_price":  0.0,
+					"min_hourly_cost":        0.17,
+					"max_hourly_cost":        0.17,
+					"min_monthly_cost":       124.1,
+					"max_monthly_cost":       124.1,
+				},
+			},
+			"min_hourly_cost":  1.635,
+			"max_hourly_cost":  1.76,
+			"min_monthly_cost": 1193.55,
+			"max_monthly_cost": 1284.8,
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClusterCostEstimateDataSourceRead_autoscale(t *testing.T) {
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			costEstimateSupportedTypesOperation(),
+		},
+		Resource:             dataSourceClusterCostEstimate(),
+		OperationContextFunc: dataSourceClusterCostEstimate().ReadContext,
+		State: map[string]interface{}{
+			"cloud_provider": api.AWS.String(),
+			"region":         "us-east-2",
+			"head": []interface{}{
+				map[string]interface{}{
+					"instance_type": "m5.2xlarge",
+				},
+			},
+			"autoscale": []interface{}{
+				map[string]interface{}{
+					"non_gpu_workers": []interface{}{
+						map[string]interface{}{
+							"instance_type": "m5.xlarge",
+							"min_workers":   1,
+							"max_workers":   10,
+							"spot_config": []interface{}{
+								map[string]interface{}{
+									"max_price_percent":   100,
+									"fall_back_on_demand": false,
+								},
+							},
+						},
+					},
+				},
+			},
+			"rondb": []interface{}{
+				map[string]interface{}{
+					"single_node": []interface{}{
+						map[string]interface{}{
+							"instance_type": "r5.xlarge",
+						},
+					},
+				},
+			},
+		},
+		ExpectState: map[string]interface{}{
+			"node_groups": []interface{}{
+				map[string]interface{}{
+					"node_type":              api.HeadNode.String(),
+					"instance_type":          "m5.2xlarge",
+					"autoscale":              false,
+					"spot":                   false,
+					"min_count":              1,
+					"max_count":              1,
+					"on_demand_hourly_price": 0.4,
+					"spot_max_hourly_price":  0.0,
+					"min_hourly_cost":        0.4,
+					"max_hourly_cost":        0.4,
+					"min_monthly_cost":       292.0,
+					"max_monthly_cost":       292.0,
+				},
+				map[string]interface{}{
+					"node_type":              api.WorkerNode.String(),
+					"instance_type":          "m5.xlarge",
+					"autoscale":              true,
+					"spot":                   true,
+					"min_count":              1,
+					"max_count":              10,
+					"on_demand_hourly_price": 0.2,
+					"spot_max_hourly_price":  0.2,
+					"min_hourly_cost":        0.2,
+					"max_hourly_cost":        2.0,
+					"min_monthly_cost":       146.0,
+					"max_monthly_cost":       1460.0,
+				},
+				map[string]interface{}{
+					"node_type":              api.RonDBDataNode.String(),
+					"instance_type":          "r5.xlarge",
+					"autoscale":              false,
+					"spot":                   false,
+					"min_count":              1,
+					"max_count":              1,
+					"on_demand_hourly_price": 0.25,
+					"spot_max_hourly_price":  0.0,
+					"min_hourly_cost":        0.25,
+					"max_hourly_cost":        0.25,
+					"min_monthly_cost":       182.5,
+					"max_monthly_cost":       182.5,
+				},
+			},
+			"min_hourly_cost":  0.85,
+			"max_hourly_cost":  2.65,
+			"min_monthly_cost": 620.5,
+			"max_monthly_cost": 1934.5,
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClusterCostEstimateDataSourceRead_priceTable(t *testing.T) {
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			costEstimateSupportedTypesOperation(),
+		},
+		Resource:             dataSourceClusterCostEstimate(),
+		OperationContextFunc: dataSourceClusterCostEstimate().ReadContext,
+		State: map[string]interface{}{
+			"cloud_provider": api.AWS.String(),
+			"region":         "us-east-2",
+			"head": []interface{}{
+				map[string]interface{}{
+					"instance_type": "m5.2xlarge",
+				},
+			},
+			"workers": []interface{}{
+				map[string]interface{}{
+					"instance_type": "m5.4xlarge",
+					"count":         2,
+				},
+			},
+			"rondb": []interface{}{
+				map[string]interface{}{
+					"single_node": []interface{}{
+						map[string]interface{}{
+							"instance_type": "r5.xlarge",
+						},
+					},
+				},
+			},
+			"price_table": map[string]interface{}{
+				"m5.2xlarge": 0.5,
+				"m5.4xlarge": 0.8,
+			},
+		},
+		ExpectState: map[string]interface{}{
+			"min_hourly_cost":  2.35,
+			"max_hourly_cost":  2.35,
+			"min_monthly_cost": 1715.5,
+			"max_monthly_cost": 1715.5,
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClusterCostEstimateDataSourceRead_offline(t *testing.T) {
+	r := test.ResourceFixture{
+		Resource:             dataSourceClusterCostEstimate(),
+		OperationContextFunc: dataSourceClusterCostEstimate().ReadContext,
+		State: map[string]interface{}{
+			"cloud_provider": api.AZURE.String(),
+			"region":         "northeurope",
+			"head": []interface{}{
+				map[string]interface{}{
+					"instance_type": "Standard_D8_v3",
+				},
+			},
+			"rondb": []interface{}{
+				map[string]interface{}{
+					"single_node": []interface{}{
+						map[string]interface{}{
+							"instance_type": "Standard_E8s_v3",
+						},
+					},
+				},
+			},
+			"offline": true,
+			"price_table": map[string]interface{}{
+				"Standard_D8_v3":  1.0,
+				"Standard_E8s_v3": 0.5,
+			},
+			"hours_per_month": 100,
+		},
+		ExpectState: map[string]interface{}{
+			"min_hourly_cost":  1.5,
+			"max_hourly_cost":  1.5,
+			"min_monthly_cost": 150.0,
+			"max_monthly_cost": 150.0,
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClusterCostEstimateDataSourceRead_missingPrice(t *testing.T) {
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			costEstimateSupportedTypesOperation(),
+		},
+		Resource:             dataSourceClusterCostEstimate(),
+		OperationContextFunc: dataSourceClusterCostEstimate().ReadContext,
+		State: map[string]interface{}{
+			"cloud_provider": api.AWS.String(),
+			"region":         "us-east-2",
+			"head": []interface{}{
+				map[string]interface{}{
+					"instance_type": "m5.2xlarge",
+				},
+			},
+			"workers": []interface{}{
+				map[string]interface{}{
+					"instance_type": "m5.4xlarge",
+					"count":         1,
+				},
+			},
+			"rondb": []interface{}{
+				map[string]interface{}{
+					"single_node": []interface{}{
+						map[string]interface{}{
+							"instance_type": "r5.xlarge",
+						},
+					},
+				},
+			},
+		},
+		ExpectError: "no price available for instance type m5.4xlarge, you can add its hourly price to price_table",
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClusterCostEstimateDataSourceRead_missingPrices(t *testing.T) {
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			costEstimateSupportedTypesOperation(),
+		},
+		Resource:             dataSourceClusterCostEstimate(),
+		OperationContextFunc: dataSourceClusterCostEstimate().ReadContext,
+		State: map[string]interface{}{
+			"cloud_provider": api.AWS.String(),
+			"region":         "us-east-2",
+			"head": []interface{}{
+				map[string]interface{}{
+					"instance_type": "m5.2xlarge",
+				},
+			},
+			"autoscale": []interface{}{
+				map[string]interface{}{
+					"non_gpu_workers": []interface{}{
+						map[string]interface{}{
+							"instance_type": "m5.4xlarge",
+							"min_workers":   0,
+							"max_workers":   10,
+						},
+					},
+				},
+			},
+			"rondb": []interface{}{
+				map[string]interface{}{
+					"single_node": []interface{}{
+						map[string]interface{}{
+							"instance_type": "r5.2xlarge",
+						},
+					},
+				},
+			},
+		},
+		ExpectError: "no price available for instance types m5.4xlarge, r5.2xlarge, you can add their hourly prices to price_table",
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClusterCostEstimateDataSourceRead_offlineWithoutPrice(t *testing.T) {
+	r := test.ResourceFixture{
+		Resource:             dataSourceClusterCostEstimate(),
+		OperationContextFunc: dataSourceClusterCostEstimate().ReadContext,
+		State: map[string]interface{}{
+			"cloud_provider": api.AWS.String(),
+			"region":         "us-east-2",
+			"head": []interface{}{
+				map[string]interface{}{
+					"instance_type": "m5.2xlarge",
+				},
+			},
+			"rondb": []interface{}{
+				map[string]interface{}{
+					"single_node": []interface{}{
+						map[string]interface{}{
+							"instance_type": "r5.xlarge",
+						},
+					},
+				},
+			},
+			"offline": true,
+			"price_table": map[string]interface{}{
+				"m5.2xlarge": 0.4,
+			},
+		},
+		ExpectError: "no price available for instance type r5.xlarge, you can add its hourly price to price_table",
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClusterCostEstimateDataSourceRead_zeroPriceTable(t *testing.T) {
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			costEstimateSupportedTypesOperation(),
+		},
+		Resource:             dataSourceClusterCostEstimate(),
+		OperationContextFunc: dataSourceClusterCostEstimate().ReadContext,
+		State: map[string]interface{}{
+			"cloud_provider": api.AWS.String(),
+			"region":         "us-east-2",
+			"head": []interface{}{
+				map[string]interface{}{
+					"instance_type": "m5.2xlarge",
+				},
+			},
+			"rondb": []interface{}{
+				map[string]interface{}{
+					"single_node": []interface{}{
+						map[string]interface{}{
+							"instance_type": "r5.xlarge",
+						},
+					},
+				},
+			},
+			"price_table": map[string]interface{}{
+				"r5.xlarge": 0,
+			},
+		},
+		ExpectError: "invalid price 0 for instance type r5.xlarge in price_table, the price must be greater than 0",
+	}
+	r.Apply(t, context.TODO())
+}
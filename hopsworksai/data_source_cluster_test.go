@@ -184,6 +184,30 @@ func TestClusterDataSourceRead_AWS(t *testing.T) {
 			"issue_lets_encrypt_certificate": true,
 			"managed_users":                  true,
 			"backup_retention_period":        10,
+			"endpoints": []interface{}{
+				map[string]interface{}{
+					"public": []interface{}{
+						map[string]interface{}{
+							"rest_api":                   "https://cluster-url/hopsworks-api/api",
+							"feature_store_jdbc":         "",
+							"hive_metastore":             "",
+							"online_feature_store_mysql": "",
+							"kafka_bootstrap_servers":    "",
+							"arrow_flight":               "",
+						},
+					},
+					"private": []interface{}{
+						map[string]interface{}{
+							"rest_api":                   "https://headIp/hopsworks-api/api",
+							"feature_store_jdbc":         "jdbc:hive2://headIp:9085",
+							"hive_metastore":             "thrift://headIp:9083",
+							"online_feature_store_mysql": "headIp:3306",
+							"kafka_bootstrap_servers":    "headIp:9092",
+							"arrow_flight":               "",
+						},
+					},
+				},
+			},
 			"aws_attributes": []interface{}{
 				map[string]interface{}{
 					"region":                    "region-1",
@@ -398,3 +422,123 @@ func TestClusterDataSourceRead_notFound(t *testing.T) {
 	}
 	r.Apply(t, context.TODO())
 }
+
+func testClusterDataSourceLookupOperations() []test.Operation {
+	return []test.Operation{
+		{
+			Method: http.MethodGet,
+			Path:   "/api/clusters",
+			Response: `{
+				"apiVersion": "v1",
+				"statue": "ok",
+				"code": 200,
+				"payload":{
+					"clusters": [
+						{
+							"id": "cluster-id-1",
+							"name": "cluster-name-1",
+							"state" : "running",
+							"provider": "AWS",
+							"tags": [
+								{
+									"name": "team",
+									"value": "data"
+								},
+								{
+									"name": "env",
+									"value": "prod"
+								}
+							]
+						},
+						{
+							"id": "cluster-id-2",
+							"name": "cluster-name-2",
+							"state" : "stopped",
+							"provider": "AWS",
+							"tags": [
+								{
+									"name": "team",
+									"value": "data"
+								},
+								{
+									"name": "env",
+									"value": "dev"
+								}
+							]
+						}
+					]
+				}
+			}`,
+		},
+	}
+}
+
+func TestClusterDataSourceRead_byName(t *testing.T) {
+	r := &test.ResourceFixture{
+		HttpOps:              testClusterDataSourceLookupOperations(),
+		Resource:             dataSourceCluster(),
+		OperationContextFunc: dataSourceCluster().ReadContext,
+		State: map[string]interface{}{
+			"name": "cluster-name-2",
+		},
+		ExpectId: "cluster-id-2",
+		ExpectState: map[string]interface{}{
+			"cluster_id": "cluster-id-2",
+			"name":       "cluster-name-2",
+			"state":      "stopped",
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClusterDataSourceRead_byTags(t *testing.T) {
+	r := &test.ResourceFixture{
+		HttpOps:              testClusterDataSourceLookupOperations(),
+		Resource:             dataSourceCluster(),
+		OperationContextFunc: dataSourceCluster().ReadContext,
+		State: map[string]interface{}{
+			"tags": map[string]interface{}{
+				"team": "data",
+				"env":  "prod",
+			},
+		},
+		ExpectId: "cluster-id-1",
+		ExpectState: map[string]interface{}{
+			"cluster_id": "cluster-id-1",
+			"name":       "cluster-name-1",
+			"tags": map[string]interface{}{
+				"team": "data",
+				"env":  "prod",
+			},
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClusterDataSourceRead_byNameNotFound(t *testing.T) {
+	r := &test.ResourceFixture{
+		HttpOps:              testClusterDataSourceLookupOperations(),
+		Resource:             dataSourceCluster(),
+		OperationContextFunc: dataSourceCluster().ReadContext,
+		State: map[string]interface{}{
+			"name": "cluster-name-3",
+		},
+		ExpectError: "cluster not found for name cluster-name-3",
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClusterDataSourceRead_byTagsMultipleMatches(t *testing.T) {
+	r := &test.ResourceFixture{
+		HttpOps:              testClusterDataSourceLookupOperations(),
+		Resource:             dataSourceCluster(),
+		OperationContextFunc: dataSourceCluster().ReadContext,
+		State: map[string]interface{}{
+			"tags": map[string]interface{}{
+				"team": "data",
+			},
+		},
+		ExpectError: "found 2 clusters for tags team=data (cluster-id-1, cluster-id-2), use cluster_id instead",
+	}
+	r.Apply(t, context.TODO())
+}
@@ -2,9 +2,13 @@ package hopsworksai
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"sort"
 	"strconv"
-	"time"
+	"strings"
 
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -18,7 +22,7 @@ func dataSourceClusters() *schema.Resource {
 		Description: "Use this data source to get information about all your clusters in Hopsworks.ai",
 		Schema: map[string]*schema.Schema{
 			"clusters": {
-				Description: "The list of clusters in the user's account.",
+				Description: "The list of clusters in the user's account sorted based on creation date with the oldest created cluster first.",
 				Type:        schema.TypeList,
 				Computed:    true,
 				Elem: &schema.Resource{
@@ -26,7 +30,7 @@ func dataSourceClusters() *schema.Resource {
 				},
 			},
 			"filter": {
-				Description: "Filter requested clusters based on cloud provider.",
+				Description: "Filter requested clusters. The cloud filter is applied by Hopsworks.ai while the other filters are applied by the provider.",
 				Type:        schema.TypeList,
 				Optional:    true,
 				MaxItems:    1,
@@ -38,14 +42,181 @@ func dataSourceClusters() *schema.Resource {
 							Optional:     true,
 							ValidateFunc: validation.StringInSlice([]string{api.AWS.String(), api.AZURE.String(), api.GCP.String()}, false),
 						},
+						"state": {
+							Description: "Filter based on the current state of the cluster.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"activation_state": {
+							Description:  "Filter based on the current activation state of the cluster.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{api.Startable.String(), api.Stoppable.String(), api.Terminable.String()}, false),
+						},
+						"name_regex": {
+							Description:  "Filter based on a regular expression that the cluster name should match.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsValidRegExp,
+						},
+						"version": {
+							Description:  "Filter based on a version constraint that the cluster version should satisfy, for example \">= 3.7, < 3.8\".",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateVersionConstraint,
+						},
+						"tags": {
+							Description: "Filter based on tags, the cluster should have all the specified tags.",
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"region": {
+							Description: "Filter based on the AWS region, the Azure location, or the GCP region or zone of the cluster.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
 					},
 				},
 			},
+			"ids_only": {
+				Description: "Only return the ids and names of the clusters and leave the clusters attribute empty. This can be useful for accounts with many clusters.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"ids": {
+				Description: "The ids of the clusters.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"names": {
+				Description: "The names of the clusters in the same order as ids.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 		ReadContext: dataSourceClustersRead,
 	}
 }
 
+func validateVersionConstraint(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if _, err := version.NewConstraint(v); err != nil {
+		return nil, []error{fmt.Errorf("invalid version constraint %s for %s: %s", v, k, err)}
+	}
+	return nil, nil
+}
+
+type clustersFilter struct {
+	state           string
+	activationState string
+	nameRegex       *regexp.Regexp
+	version         version.Constraints
+	tags            map[string]interface{}
+	region          string
+}
+
+func expandClustersFilter(d *schema.ResourceData) (*clustersFilter, error) {
+	filter := &clustersFilter{}
+	if v, ok := d.GetOk("filter.0.state"); ok {
+		filter.state = v.(string)
+	}
+	if v, ok := d.GetOk("filter.0.activation_state"); ok {
+		filter.activationState = v.(string)
+	}
+	if v, ok := d.GetOk("filter.0.name_regex"); ok {
+		nameRegex, err := regexp.Compile(v.(string))
+		if err != nil {
+			return nil, err
+		}
+		filter.nameRegex = nameRegex
+	}
+	if v, ok := d.GetOk("filter.0.version"); ok {
+		constraints, err := version.NewConstraint(v.(string))
+		if err != nil {
+			return nil, err
+		}
+		filter.version = constraints
+	}
+	if v, ok := d.GetOk("filter.0.tags"); ok {
+		filter.tags = v.(map[string]interface{})
+	}
+	if v, ok := d.GetOk("filter.0.region"); ok {
+		filter.region = v.(string)
+	}
+	return filter, nil
+}
+
+func (f *clustersFilter) String() string {
+	var nameRegex, versionConstraint string
+	if f.nameRegex != nil {
+		nameRegex = f.nameRegex.String()
+	}
+	if f.version != nil {
+		versionConstraint = f.version.String()
+	}
+	return fmt.Sprintf("state=%s;activation_state=%s;name_regex=%s;version=%s;tags=%s;region=%s",
+		f.state, f.activationState, nameRegex, versionConstraint, formatTags(f.tags), f.region)
+}
+
+func clusterRegion(cluster *api.Cluster) []string {
+	switch cluster.Provider {
+	case api.AWS:
+		return []string{cluster.AWS.Region}
+	case api.AZURE:
+		return []string{cluster.Azure.Location}
+	case api.GCP:
+		return []string{cluster.GCP.Region, cluster.GCP.Zone}
+	}
+	return []string{}
+}
+
+func (f *clustersFilter) matches(cluster *api.Cluster) bool {
+	if f.state != "" && cluster.State.String() != f.state {
+		return false
+	}
+	if f.activationState != "" && cluster.ActivationState.String() != f.activationState {
+		return false
+	}
+	if f.nameRegex != nil && !f.nameRegex.MatchString(cluster.Name) {
+		return false
+	}
+	if f.version != nil {
+		clusterVersion, err := version.NewVersion(cluster.Version)
+		if err != nil || !f.version.Check(clusterVersion) {
+			return false
+		}
+	}
+	if !clusterHasTags(cluster, f.tags) {
+		return false
+	}
+	if f.region != "" {
+		found := false
+		for _, region := range clusterRegion(cluster) {
+			if region == f.region {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
 func dataSourceClustersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	client := meta.(*api.HopsworksAIClient)
@@ -55,18 +226,52 @@ func dataSourceClustersRead(ctx context.Context, d *schema.ResourceData, meta in
 		cloud = api.CloudProvider(v.(string))
 	}
 
-	clustersArray, err := api.GetClusters(ctx, client, cloud)
+	filter, err := expandClustersFilter(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	allClusters, err := api.GetClusters(ctx, client, cloud)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	clustersArray := make([]api.Cluster, 0, len(allClusters))
+	for i := range allClusters {
+		if filter.matches(&allClusters[i]) {
+			clustersArray = append(clustersArray, allClusters[i])
+		}
+	}
+
+	sort.SliceStable(clustersArray, func(i, j int) bool {
+		if clustersArray[i].CreatedOn != clustersArray[j].CreatedOn {
+			return clustersArray[i].CreatedOn < clustersArray[j].CreatedOn
+		}
+		return clustersArray[i].Id < clustersArray[j].Id
+	})
+	ids := make([]string, len(clustersArray))
+	names := make([]string, len(clustersArray))
+	for i := range clustersArray {
+		ids[i] = clustersArray[i].Id
+		names[i] = clustersArray[i].Name
+	}
+
+	if d.Get("ids_only").(bool) {
+		clustersArray = []api.Cluster{}
+	}
+
 	clusters := structure.FlattenClusters(clustersArray)
 	if err := d.Set("clusters", clusters); err != nil {
 		return diag.Errorf("data passed %s, err: %s", clusters, err)
 	}
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("names", names); err != nil {
+		return diag.FromErr(err)
+	}
 
-	// always run
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	d.SetId(strconv.Itoa(schema.HashString(fmt.Sprintf("%s:%s:%t:%s", cloud, filter, d.Get("ids_only").(bool), strings.Join(ids, ",")))))
 
 	return diags
 }
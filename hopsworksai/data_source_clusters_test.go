@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -450,6 +451,188 @@ func TestClustersDataSourceRead_filter(t *testing.T) {
 	r.Apply(t, context.TODO())
 }
 
+func testClustersDataSourceFilterOperations() []test.Operation {
+	return []test.Operation{
+		{
+			Method: http.MethodGet,
+			Path:   "/api/clusters",
+			Response: `{
+				"apiVersion": "v1",
+				"status": "ok",
+				"code": 200,
+				"payload":{
+					"clusters":[
+						{
+							"id": "cluster-1",
+							"name": "ml-cluster-1",
+							"state": "running",
+							"activationState": "stoppable",
+							"version": "3.7.1",
+							"provider": "AWS",
+							"tags": [{"name": "team", "value": "ml"}],
+							"aws": {
+								"region": "eu-west-1"
+							}
+						},
+						{
+							"id": "cluster-2",
+							"name": "ml-cluster-2",
+							"state": "running",
+							"activationState": "stoppable",
+							"version": "3.8.0",
+							"provider": "AWS",
+							"tags": [{"name": "team", "value": "ml"}],
+							"aws": {
+								"region": "eu-west-1"
+							}
+						},
+						{
+							"id": "cluster-3",
+							"name": "ml-cluster-3",
+							"state": "stopped",
+							"activationState": "startable",
+							"version": "3.7.0",
+							"provider": "AWS",
+							"tags": [{"name": "team", "value": "ml"}],
+							"aws": {
+								"region": "eu-west-1"
+							}
+						},
+						{
+							"id": "cluster-4",
+							"name": "data-cluster",
+							"state": "running",
+							"activationState": "stoppable",
+							"version": "3.7.0",
+							"provider": "GCP",
+							"tags": [{"name": "team", "value": "data"}],
+							"gcp": {
+								"region": "europe-north1",
+								"zone": "europe-north1-a"
+							}
+						}
+					]
+				}
+			}`,
+		},
+	}
+}
+
+func TestClustersDataSourceRead_clientSideFilter(t *testing.T) {
+	r := test.ResourceFixture{
+		HttpOps:              testClustersDataSourceFilterOperations(),
+		Resource:             dataSourceClusters(),
+		OperationContextFunc: dataSourceClusters().ReadContext,
+		State: map[string]interface{}{
+			"filter": []interface{}{
+				map[string]interface{}{
+					"state":      "running",
+					"name_regex": "^ml-.*",
+					"version":    ">= 3.7, < 3.8",
+					"tags": map[string]interface{}{
+						"team": "ml",
+					},
+					"region": "eu-west-1",
+				},
+			},
+		},
+		ExpectState: map[string]interface{}{
+			"ids":   []interface{}{"cluster-1"},
+			"names": []interface{}{"ml-cluster-1"},
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClustersDataSourceRead_filterByZone(t *testing.T) {
+	r := test.ResourceFixture{
+		HttpOps:              testClustersDataSourceFilterOperations(),
+		Resource:             dataSourceClusters(),
+		OperationContextFunc: dataSourceClusters().ReadContext,
+		State: map[string]interface{}{
+			"filter": []interface{}{
+				map[string]interface{}{
+					"region":           "europe-north1-a",
+					"activation_state": "stoppable",
+				},
+			},
+		},
+		ExpectState: map[string]interface{}{
+			"ids":   []interface{}{"cluster-4"},
+			"names": []interface{}{"data-cluster"},
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClustersDataSourceRead_idsOnly(t *testing.T) {
+	r := test.ResourceFixture{
+		HttpOps:              testClustersDataSourceFilterOperations(),
+		Resource:             dataSourceClusters(),
+		OperationContextFunc: dataSourceClusters().ReadContext,
+		State: map[string]interface{}{
+			"ids_only": true,
+			"filter": []interface{}{
+				map[string]interface{}{
+					"version": "~> 3.7.0",
+				},
+			},
+		},
+		ExpectState: map[string]interface{}{
+			"clusters": []interface{}{},
+			"ids":      []interface{}{"cluster-1", "cluster-3", "cluster-4"},
+			"names":    []interface{}{"ml-cluster-1", "ml-cluster-3", "data-cluster"},
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClustersDataSourceRead_sorted(t *testing.T) {
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			{
+				Method: http.MethodGet,
+				Path:   "/api/clusters",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200,
+					"payload":{
+						"clusters":[
+							{
+								"id": "cluster-c",
+								"name": "cluster-c",
+								"createdOn": 2
+							},
+							{
+								"id": "cluster-b",
+								"name": "cluster-b",
+								"createdOn": 1
+							},
+							{
+								"id": "cluster-a",
+								"name": "cluster-a",
+								"createdOn": 2
+							}
+						]
+					}
+				}`,
+			},
+		},
+		Resource:             dataSourceClusters(),
+		OperationContextFunc: dataSourceClusters().ReadContext,
+		State: map[string]interface{}{
+			"ids_only": true,
+		},
+		ExpectId: strconv.Itoa(schema.HashString(":state=;activation_state=;name_regex=;version=;tags=;region=:true:cluster-b,cluster-a,cluster-c")),
+		ExpectState: map[string]interface{}{
+			"ids":   []interface{}{"cluster-b", "cluster-a", "cluster-c"},
+			"names": []interface{}{"cluster-b", "cluster-a", "cluster-c"},
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
 func TestClustersDataSourceRead_error(t *testing.T) {
 	r := test.ResourceFixture{
 		HttpOps: []test.Operation{
@@ -0,0 +1,92 @@
+package hopsworksai
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGCPPolicyCheck() *schema.Resource {
+	policyCheckSchema := policyCheckInputSchema(dataSourceGCPServiceAccountCustomRolePermissions().Schema,
+		"permissions", "condition", "role_yaml", "role_json", "title", "description", "stage", "bucket_name")
+	policyCheckSchema["permissions"] = &schema.Schema{
+		Description: "The permissions of the custom role to check.",
+		Type:        schema.TypeList,
+		Required:    true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+	policyCheckSchema["fail_on_missing"] = &schema.Schema{
+		Description: "Fail if the custom role is missing any of the required permissions.",
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+	}
+	policyCheckSchema["missing_permissions"] = &schema.Schema{
+		Description: "The required permissions that are not included in the custom role.",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+	policyCheckSchema["extra_permissions"] = &schema.Schema{
+		Description: "The permissions included in the custom role that are not needed by Hopsworks.ai.",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+
+	return &schema.Resource{
+		Description: "Use this data source to check whether a GCP custom role covers the service account permissions needed by Hopsworks.ai. The check is done offline.",
+		Schema:      policyCheckSchema,
+		ReadContext: dataSourceGCPPolicyCheckRead,
+	}
+}
+
+func dataSourceGCPPolicyCheckRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	required := gcpServiceAccountCustomRolePermissions(d)
+	permissions := toStringList(d.Get("permissions").([]interface{}))
+
+	requiredSet := make(map[string]bool, len(required))
+	for _, p := range required {
+		requiredSet[p] = true
+	}
+	permissionsSet := make(map[string]bool, len(permissions))
+	for _, p := range permissions {
+		permissionsSet[p] = true
+	}
+
+	missingPermissions := make([]string, 0)
+	for _, p := range required {
+		if !permissionsSet[p] {
+			missingPermissions = append(missingPermissions, p)
+		}
+	}
+
+	extraPermissions := make([]string, 0)
+	for _, p := range permissions {
+		if !requiredSet[p] {
+			extraPermissions = append(extraPermissions, p)
+		}
+	}
+
+	if d.Get("fail_on_missing").(bool) && len(missingPermissions) > 0 {
+		return diag.Errorf("the custom role is missing the following required permissions: %s", strings.Join(missingPermissions, ", "))
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(strings.Join(required, ",") + strings.Join(permissions, ","))))
+	if err := d.Set("missing_permissions", missingPermissions); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("extra_permissions", extraPermissions); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
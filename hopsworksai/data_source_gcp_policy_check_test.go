@@ -0,0 +1,53 @@
+package hopsworksai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/test"
+)
+
+func TestGCPPolicyCheckRead(t *testing.T) {
+	r := test.ResourceFixture{
+		Resource:             dataSourceGCPPolicyCheck(),
+		OperationContextFunc: dataSourceGCPPolicyCheck().ReadContext,
+		State: map[string]interface{}{
+			"enable_storage": false,
+			"enable_logging": true,
+			"permissions": []interface{}{
+				"storage.buckets.update",
+				"artifactregistry.repositories.create",
+				"artifactregistry.repositories.get",
+				"artifactregistry.repositories.uploadArtifacts",
+				"artifactregistry.repositories.downloadArtifacts",
+				"artifactregistry.repositories.delete",
+				"artifactregistry.tags.list",
+				"artifactregistry.tags.delete",
+			},
+		},
+		ExpectState: map[string]interface{}{
+			"missing_permissions": []interface{}{
+				"logging.logEntries.create",
+			},
+			"extra_permissions": []interface{}{
+				"artifactregistry.repositories.delete",
+			},
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestGCPPolicyCheckRead_failOnMissing(t *testing.T) {
+	r := test.ResourceFixture{
+		Resource:             dataSourceGCPPolicyCheck(),
+		OperationContextFunc: dataSourceGCPPolicyCheck().ReadContext,
+		State: map[string]interface{}{
+			"enable_storage":           false,
+			"enable_artifact_registry": false,
+			"fail_on_missing":          true,
+			"permissions":              []interface{}{},
+		},
+		ExpectError: "the custom role is missing the following required permissions: storage.buckets.update",
+	}
+	r.Apply(t, context.TODO())
+}
@@ -2,11 +2,14 @@ package hopsworksai
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataSourceGCPServiceAccountCustomRolePermissions() *schema.Resource {
@@ -31,6 +34,42 @@ func dataSourceGCPServiceAccountCustomRolePermissions() *schema.Resource {
 				Optional:    true,
 				Default:     true,
 			},
+			"enable_gke": {
+				Description: "Add permissions required to enable access to Google GKE from within your Hopsworks cluster.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"enable_logging": {
+				Description: "Add permissions required to allow collecting your cluster logs using Cloud Logging.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"bucket_name": {
+				Description: "Limit storage permissions to this google storage bucket using an IAM condition.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"title": {
+				Description: "The title of the custom role.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "Hopsworks.ai",
+			},
+			"description": {
+				Description: "The description of the custom role.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "The permissions needed by the service account of Hopsworks clusters",
+			},
+			"stage": {
+				Description:  "The launch stage of the custom role.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "GA",
+				ValidateFunc: validation.StringInSlice([]string{"ALPHA", "BETA", "GA", "DEPRECATED", "DISABLED", "EAP"}, false),
+			},
 			"permissions": {
 				Description: "The list of permissions.",
 				Type:        schema.TypeList,
@@ -39,12 +78,77 @@ func dataSourceGCPServiceAccountCustomRolePermissions() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"condition": {
+				Description: "The IAM condition to use when granting the custom role to limit the storage permissions to bucket_name.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"title": {
+							Description: "The title of the condition.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"description": {
+							Description: "The description of the condition.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"expression": {
+							Description: "The condition expression.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"role_yaml": {
+				Description: "The custom role definition in YAML format.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"role_json": {
+				Description: "The custom role definition in JSON format.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 		},
 		ReadContext: dataSourceGCPServiceAccountCustomRolePermissionsRead,
 	}
 }
 
-func dataSourceGCPServiceAccountCustomRolePermissionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+type gcpCustomRole struct {
+	Title               string   `json:"title"`
+	Description         string   `json:"description"`
+	Stage               string   `json:"stage"`
+	IncludedPermissions []string `json:"includedPermissions"`
+}
+
+func (r *gcpCustomRole) yaml() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("title: %s\n", strconv.Quote(r.Title)))
+	b.WriteString(fmt.Sprintf("description: %s\n", strconv.Quote(r.Description)))
+	b.WriteString(fmt.Sprintf("stage: %s\n", r.Stage))
+	if len(r.IncludedPermissions) == 0 {
+		b.WriteString("includedPermissions: []\n")
+		return b.String()
+	}
+	b.WriteString("includedPermissions:\n")
+	for _, permission := range r.IncludedPermissions {
+		b.WriteString(fmt.Sprintf("- %s\n", permission))
+	}
+	return b.String()
+}
+
+// gcpBucketConditionExpression limits the storage permissions to the bucket and its objects
+// while keeping the other permissions of the role unrestricted.
+func gcpBucketConditionExpression(bucketName string) string {
+	bucketResource := fmt.Sprintf("projects/_/buckets/%s", bucketName)
+	return fmt.Sprintf(`!resource.type.startsWith("storage.googleapis.com/") || resource.name == "%s" || resource.name.startsWith("%s/")`, bucketResource, bucketResource)
+}
+
+// gcpServiceAccountCustomRolePermissions returns the permissions based on the data source inputs.
+func gcpServiceAccountCustomRolePermissions(d *schema.ResourceData) []string {
 	permissions := []string{}
 
 	if d.Get("enable_storage").(bool) {
@@ -73,9 +177,54 @@ func dataSourceGCPServiceAccountCustomRolePermissionsRead(ctx context.Context, d
 			"artifactregistry.tags.delete")
 	}
 
+	if d.Get("enable_gke").(bool) {
+		permissions = append(permissions, "container.clusters.get",
+			"container.clusters.getCredentials")
+	}
+
+	if d.Get("enable_logging").(bool) {
+		permissions = append(permissions, "logging.logEntries.create")
+	}
+	return permissions
+}
+
+func dataSourceGCPServiceAccountCustomRolePermissionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	permissions := gcpServiceAccountCustomRolePermissions(d)
+
+	condition := []interface{}{}
+	if v, ok := d.GetOk("bucket_name"); ok {
+		bucketName := v.(string)
+		condition = append(condition, map[string]interface{}{
+			"title":       fmt.Sprintf("hopsworks-bucket-%s", bucketName),
+			"description": fmt.Sprintf("Limit the storage permissions to the bucket %s", bucketName),
+			"expression":  gcpBucketConditionExpression(bucketName),
+		})
+	}
+
+	role := &gcpCustomRole{
+		Title:               d.Get("title").(string),
+		Description:         d.Get("description").(string),
+		Stage:               d.Get("stage").(string),
+		IncludedPermissions: permissions,
+	}
+
+	roleJson, err := json.MarshalIndent(role, "", "  ")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	d.SetId(strconv.Itoa(schema.HashString(strings.Join(permissions, ","))))
 	if err := d.Set("permissions", permissions); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := d.Set("condition", condition); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("role_yaml", role.yaml()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("role_json", string(roleJson)); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
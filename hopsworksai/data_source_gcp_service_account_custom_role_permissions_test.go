@@ -1,9 +1,11 @@
 package hopsworksai
 
 import (
+	"context"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/test"
 )
 
 func TestAccGCPServiceAccountCustomRole_basic(t *testing.T) {
@@ -104,6 +106,86 @@ func TestAccGCPServiceAccountCustomRole_noPerm(t *testing.T) {
 	})
 }
 
+func TestAccGCPServiceAccountCustomRole_gkeAndLogging(t *testing.T) {
+	dataSourceName := "data.hopsworksai_gcp_service_account_custom_role_permissions.test"
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGCPServiceAccountCustomRole_gkeAndLogging(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "permissions.#", "3"),
+					resource.TestCheckResourceAttr(dataSourceName, "permissions.0", "container.clusters.get"),
+					resource.TestCheckResourceAttr(dataSourceName, "permissions.1", "container.clusters.getCredentials"),
+					resource.TestCheckResourceAttr(dataSourceName, "permissions.2", "logging.logEntries.create"),
+					resource.TestCheckResourceAttr(dataSourceName, "condition.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestGCPServiceAccountCustomRoleRead_bucketCondition(t *testing.T) {
+	r := test.ResourceFixture{
+		Resource:             dataSourceGCPServiceAccountCustomRolePermissions(),
+		OperationContextFunc: dataSourceGCPServiceAccountCustomRolePermissions().ReadContext,
+		State: map[string]interface{}{
+			"enable_backup":            false,
+			"enable_artifact_registry": false,
+			"bucket_name":              "my-bucket",
+		},
+		ExpectState: map[string]interface{}{
+			"condition": []interface{}{
+				map[string]interface{}{
+					"title":       "hopsworks-bucket-my-bucket",
+					"description": "Limit the storage permissions to the bucket my-bucket",
+					"expression":  `!resource.type.startsWith("storage.googleapis.com/") || resource.name == "projects/_/buckets/my-bucket" || resource.name.startsWith("projects/_/buckets/my-bucket/")`,
+				},
+			},
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestGCPServiceAccountCustomRoleRead_role(t *testing.T) {
+	r := test.ResourceFixture{
+		Resource:             dataSourceGCPServiceAccountCustomRolePermissions(),
+		OperationContextFunc: dataSourceGCPServiceAccountCustomRolePermissions().ReadContext,
+		State: map[string]interface{}{
+			"enable_storage":           false,
+			"enable_artifact_registry": false,
+			"enable_gke":               true,
+			"enable_logging":           true,
+			"title":                    "Hopsworks role",
+			"stage":                    "BETA",
+		},
+		ExpectState: map[string]interface{}{
+			"condition": []interface{}{},
+			"role_yaml": `title: "Hopsworks role"
+description: "The permissions needed by the service account of Hopsworks clusters"
+stage: BETA
+includedPermissions:
+- storage.buckets.update
+- container.clusters.get
+- container.clusters.getCredentials
+- logging.logEntries.create
+`,
+			"role_json": `{
+  "title": "Hopsworks role",
+  "description": "The permissions needed by the service account of Hopsworks clusters",
+  "stage": "BETA",
+  "includedPermissions": [
+    "storage.buckets.update",
+    "container.clusters.get",
+    "container.clusters.getCredentials",
+    "logging.logEntries.create"
+  ]
+}`,
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
 func testAccGCPServiceAccountCustomRole_basic() string {
 	return `
 	data "hopsworksai_gcp_service_account_custom_role_permissions" "test" {
@@ -137,3 +219,15 @@ func testAccGCPServiceAccountCustomRole_noPerm() string {
 	}
 	`
 }
+
+func testAccGCPServiceAccountCustomRole_gkeAndLogging() string {
+	return `
+	data "hopsworksai_gcp_service_account_custom_role_permissions" "test" {
+		enable_storage = false
+		enable_backup = false
+		enable_artifact_registry = false
+		enable_gke = true
+		enable_logging = true
+	}
+	`
+}
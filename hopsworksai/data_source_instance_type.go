@@ -3,17 +3,19 @@ package hopsworksai
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/api"
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/helpers"
 )
 
 func dataSourceInstanceType() *schema.Resource {
 	return &schema.Resource{
-		Description: "Use this data source to get the smallest instance type for head, worker, and RonDB nodes.",
+		Description: "Use this data source to get the smallest, cheapest, or best memory per CPU instance type for head, worker, and RonDB nodes.",
 		Schema: map[string]*schema.Schema{
 			"node_type": {
 				Description:  fmt.Sprintf("The node type that you want to get its smallest instance type. It has to be one of these types (%s).", strings.Join(api.GetAllNodeTypes(), ", ")),
@@ -47,7 +49,69 @@ func dataSourceInstanceType() *schema.Resource {
 				ValidateFunc: validation.IntAtLeast(0),
 			},
 			"with_nvme": {
-				Description: "Filter based on the presence of NVMe drives.",
+				Description: "Filter based on the presence of NVMe drives. If set to true, only the instance types equipped with NVMe drives match, and if set to false, only the instance types without NVMe drives match.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"max_memory_gb": {
+				Description:  "Filter based on the maximum memory in gigabytes. The default value -1 means no upper bound.",
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      -1,
+				ValidateFunc: validation.Any(validation.FloatAtLeast(0), validation.FloatBetween(-1, -1)),
+			},
+			"max_cpus": {
+				Description:  "Filter based on the maximum number of CPU cores. The default value -1 means no upper bound.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      -1,
+				ValidateFunc: validation.Any(validation.IntAtLeast(0), validation.IntInSlice([]int{-1})),
+			},
+			"min_gpus": {
+				Description:  "Filter based on the minimum number of GPUs.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_gpus": {
+				Description:  "Filter based on the maximum number of GPUs. The default value -1 means no upper bound.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      -1,
+				ValidateFunc: validation.Any(validation.IntAtLeast(0), validation.IntInSlice([]int{-1})),
+			},
+			"gpu_type": {
+				Description:  "Filter based on the GPU type such as nvidia-tesla-t4.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"architecture": {
+				Description:  fmt.Sprintf("Filter based on the CPU architecture. It has to be one of these types (%s).", strings.Join(api.GetAllCPUArchitectures(), ", ")),
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(api.GetAllCPUArchitectures(), false),
+			},
+			"exclude": {
+				Description: "The list of instance types to exclude. You can use the wildcards * and ? to exclude a family of instance types such as m5d.*.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+			"strategy": {
+				Description:  fmt.Sprintf("The strategy used to rank the matching instance types. It has to be one of these strategies (%s). The cheapest strategy uses the hourly price reported by hopsworks.ai, ranks the instance types without a price last, and fails if none of the matching instance types has a price.", strings.Join(instanceTypeStrategies(), ", ")),
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      instanceTypeStrategySmallest,
+				ValidateFunc: validation.StringInSlice(instanceTypeStrategies(), false),
+			},
+			"fail_if_no_match": {
+				Description: "Fail if no instance type matches the filters. If not set, the smallest instance type is returned with a warning.",
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
@@ -57,6 +121,115 @@ func dataSourceInstanceType() *schema.Resource {
 	}
 }
 
+const (
+	instanceTypeStrategySmallest         = "smallest"
+	instanceTypeStrategyCheapest         = "cheapest"
+	instanceTypeStrategyBestMemoryPerCPU = "best_memory_per_cpu"
+)
+
+func instanceTypeStrategies() []string {
+	return []string{
+		instanceTypeStrategySmallest,
+		instanceTypeStrategyCheapest,
+		instanceTypeStrategyBestMemoryPerCPU,
+	}
+}
+
+type instanceTypeFilter struct {
+	minMemory    float64
+	maxMemory    float64
+	minCPUs      int
+	maxCPUs      int
+	minGPUs      int
+	maxGPUs      int
+	gpuType      string
+	architecture api.CPUArchitecture
+	withNVMe     *bool
+	idPrefix     string
+	idRegex      *regexp.Regexp
+	exclude      []string
+}
+
+func expandInstanceTypeFilter(d *schema.ResourceData, prefix string) (*instanceTypeFilter, error) {
+	withNVMe := d.Get(prefix + "with_nvme").(bool)
+	filter := &instanceTypeFilter{
+		minMemory:    d.Get(prefix + "min_memory_gb").(float64),
+		maxMemory:    d.Get(prefix + "max_memory_gb").(float64),
+		minCPUs:      d.Get(prefix + "min_cpus").(int),
+		maxCPUs:      d.Get(prefix + "max_cpus").(int),
+		minGPUs:      d.Get(prefix + "min_gpus").(int),
+		maxGPUs:      d.Get(prefix + "max_gpus").(int),
+		gpuType:      d.Get(prefix + "gpu_type").(string),
+		architecture: api.CPUArchitecture(d.Get(prefix + "architecture").(string)),
+		withNVMe:     &withNVMe,
+		exclude:      getStringList(d, prefix+"exclude"),
+	}
+
+	if filter.maxMemory >= 0 && filter.minMemory > filter.maxMemory {
+		return nil, fmt.Errorf("min_memory_gb (%v) cannot be greater than max_memory_gb (%v)", filter.minMemory, filter.maxMemory)
+	}
+	if filter.maxCPUs >= 0 && filter.minCPUs > filter.maxCPUs {
+		return nil, fmt.Errorf("min_cpus (%d) cannot be greater than max_cpus (%d)", filter.minCPUs, filter.maxCPUs)
+	}
+	if filter.maxGPUs >= 0 && filter.minGPUs > filter.maxGPUs {
+		return nil, fmt.Errorf("min_gpus (%d) cannot be greater than max_gpus (%d)", filter.minGPUs, filter.maxGPUs)
+	}
+	return filter, nil
+}
+
+func (f *instanceTypeFilter) matches(instanceType *api.SupportedInstanceType) bool {
+	if f.minMemory > 0 && instanceType.Memory < f.minMemory {
+		return false
+	}
+	if f.maxMemory >= 0 && instanceType.Memory > f.maxMemory {
+		return false
+	}
+	if f.minCPUs > 0 && instanceType.CPUs < f.minCPUs {
+		return false
+	}
+	if f.maxCPUs >= 0 && instanceType.CPUs > f.maxCPUs {
+		return false
+	}
+	if f.minGPUs > 0 && instanceType.GPUs < f.minGPUs {
+		return false
+	}
+	if f.maxGPUs >= 0 && instanceType.GPUs > f.maxGPUs {
+		return false
+	}
+	if f.gpuType != "" && !strings.EqualFold(f.gpuType, instanceType.GPUType) {
+		return false
+	}
+	if f.architecture != "" && f.architecture != instanceType.GetArchitecture() {
+		return false
+	}
+	if f.withNVMe != nil && *f.withNVMe != instanceType.WithNVMe {
+		return false
+	}
+	if f.idPrefix != "" && !strings.HasPrefix(instanceType.Id, f.idPrefix) {
+		return false
+	}
+	if f.idRegex != nil && !f.idRegex.MatchString(instanceType.Id) {
+		return false
+	}
+	for _, pattern := range f.exclude {
+		if helpers.WildcardMatch(pattern, instanceType.Id) {
+			return false
+		}
+	}
+	return true
+}
+
+func rankInstanceTypes(instanceTypes api.SupportedInstanceTypeList, strategy string) {
+	switch strategy {
+	case instanceTypeStrategyCheapest:
+		instanceTypes.SortByPrice()
+	case instanceTypeStrategyBestMemoryPerCPU:
+		instanceTypes.SortByMemoryPerCPU()
+	default:
+		instanceTypes.Sort()
+	}
+}
+
 func dataSourceInstanceTypeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*api.HopsworksAIClient)
 
@@ -74,32 +247,41 @@ func dataSourceInstanceTypeRead(ctx context.Context, d *schema.ResourceData, met
 		return diag.Errorf("no instance types available for %s", nodeType)
 	}
 
-	instanceTypesArr.Sort()
+	filter, err := expandInstanceTypeFilter(d, "")
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	minMemory := d.Get("min_memory_gb").(float64)
-	minCPUs := d.Get("min_cpus").(int)
-	withNVMe := d.Get("with_nvme").(bool)
+	rankInstanceTypes(instanceTypesArr, d.Get("strategy").(string))
 
 	var chosenType *api.SupportedInstanceType = nil
-	for _, v := range instanceTypesArr {
-		if minMemory > 0 && v.Memory < minMemory {
-			continue
-		}
-		if minCPUs > 0 && v.CPUs < minCPUs {
-			continue
+	for i := range instanceTypesArr {
+		if filter.matches(&instanceTypesArr[i]) {
+			chosenType = &instanceTypesArr[i]
+			break
 		}
-		if withNVMe != v.WithNVMe {
-			continue
-		}
-
-		chosenType = &v
-		break
 	}
 
 	if chosenType != nil {
+		// instance types without a price are ranked last, so none of the matching instance types has a price
+		if d.Get("strategy").(string) == instanceTypeStrategyCheapest && chosenType.Price == 0 {
+			return diag.Errorf("cannot use the %s strategy for %s since hopsworks.ai does not report the price of any matching instance type", instanceTypeStrategyCheapest, nodeType)
+		}
 		d.SetId(chosenType.Id)
-	} else {
-		d.SetId(instanceTypesArr[0].Id)
+		return nil
+	}
+
+	if d.Get("fail_if_no_match").(bool) {
+		return diag.Errorf("no instance type matches the filters for %s", nodeType)
+	}
+
+	instanceTypesArr.Sort()
+	d.SetId(instanceTypesArr[0].Id)
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("No instance type matches the filters for %s, falling back to %s", nodeType, instanceTypesArr[0].Id),
+			Detail:   "Set fail_if_no_match to true to fail instead of falling back to the smallest instance type.",
+		},
 	}
-	return nil
 }
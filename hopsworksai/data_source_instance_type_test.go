@@ -198,3 +198,232 @@ func testInstanceTypeDataSourceBase(t *testing.T, cloud api.CloudProvider, nodeT
 	}
 	r.Apply(t, context.TODO())
 }
+
+func TestInstanceTypeDataSourceRead_advancedFilters(t *testing.T) {
+	cases := []struct {
+		state         map[string]interface{}
+		expectedId    string
+		expectError   string
+		expectWarning string
+	}{
+		{
+			state:      map[string]interface{}{},
+			expectedId: "worker-type-1",
+		},
+		{
+			state: map[string]interface{}{
+				"min_cpus":      8,
+				"max_memory_gb": 32,
+			},
+			expectedId: "worker-type-arm",
+		},
+		{
+			state: map[string]interface{}{
+				"min_cpus":     8,
+				"architecture": api.X86_64.String(),
+			},
+			expectedId: "worker-type-2",
+		},
+		{
+			state: map[string]interface{}{
+				"architecture": api.ARM64.String(),
+			},
+			expectedId: "worker-type-arm",
+		},
+		{
+			state: map[string]interface{}{
+				"min_cpus": 8,
+				"max_cpus": 8,
+				"exclude":  []interface{}{"worker-type-a*"},
+			},
+			expectedId: "worker-type-2",
+		},
+		{
+			state: map[string]interface{}{
+				"min_gpus": 1,
+			},
+			expectedId: "worker-type-gpu-1",
+		},
+		{
+			state: map[string]interface{}{
+				"min_gpus": 2,
+			},
+			expectedId: "worker-type-gpu-4",
+		},
+		{
+			state: map[string]interface{}{
+				"min_gpus": 1,
+				"max_gpus": 1,
+				"gpu_type": "nvidia-tesla-a100",
+			},
+			expectedId:    "worker-type-1",
+			expectWarning: "No instance type matches the filters for worker, falling back to worker-type-1",
+		},
+		{
+			state: map[string]interface{}{
+				"min_gpus":         1,
+				"gpu_type":         "nvidia-tesla-a100",
+				"fail_if_no_match": true,
+			},
+			expectedId: "worker-type-gpu-4",
+		},
+		{
+			state: map[string]interface{}{
+				"min_cpus": 8,
+				"strategy": "cheapest",
+			},
+			expectedId: "worker-type-arm",
+		},
+		{
+			state: map[string]interface{}{
+				"strategy": "best_memory_per_cpu",
+			},
+			expectedId: "worker-type-2",
+		},
+		{
+			state: map[string]interface{}{
+				"min_memory_gb":    1000,
+				"fail_if_no_match": true,
+			},
+			expectError: "no instance type matches the filters for worker",
+		},
+		{
+			state: map[string]interface{}{
+				"min_cpus":         16,
+				"max_gpus":         0,
+				"fail_if_no_match": true,
+			},
+			expectError: "no instance type matches the filters for worker",
+		},
+		{
+			state: map[string]interface{}{
+				"min_cpus": 16,
+				"max_cpus": 8,
+			},
+			expectError: "min_cpus (16) cannot be greater than max_cpus (8)",
+		},
+		{
+			state: map[string]interface{}{
+				"min_memory_gb": 64,
+				"max_memory_gb": 32,
+			},
+			expectError: "min_memory_gb (64) cannot be greater than max_memory_gb (32)",
+		},
+		{
+			state: map[string]interface{}{
+				"min_gpus": 4,
+				"max_gpus": 1,
+			},
+			expectError: "min_gpus (4) cannot be greater than max_gpus (1)",
+		},
+	}
+
+	for i, c := range cases {
+		c.state["node_type"] = api.WorkerNode.String()
+		c.state["cloud_provider"] = api.AWS.String()
+		r := test.ResourceFixture{
+			HttpOps: []test.Operation{
+				{
+					Method: http.MethodGet,
+					Path:   "/api/clusters/nodes/supported-types",
+					Response: `{
+						"apiVersion": "v1",
+						"status": "ok",
+						"code": 200,
+						"payload": {
+							"aws": {
+								"worker": [
+									{
+										"id": "worker-type-1",
+										"memory": 16,
+										"cpus": 4,
+										"price": 0.2
+									},
+									{
+										"id": "worker-type-2",
+										"memory": 64,
+										"cpus": 8,
+										"price": 0.5
+									},
+									{
+										"id": "worker-type-arm",
+										"memory": 32,
+										"cpus": 8,
+										"architecture": "arm64",
+										"price": 0.3
+									},
+									{
+										"id": "worker-type-gpu-1",
+										"memory": 64,
+										"cpus": 16,
+										"gpus": 1,
+										"gpuType": "nvidia-tesla-t4",
+										"price": 1.2
+									},
+									{
+										"id": "worker-type-gpu-4",
+										"memory": 256,
+										"cpus": 32,
+										"gpus": 4,
+										"gpuType": "nvidia-tesla-a100",
+										"price": 12
+									}
+								]
+							}
+						}
+					}`,
+				},
+			},
+			Resource:             dataSourceInstanceType(),
+			OperationContextFunc: dataSourceInstanceType().ReadContext,
+			State:                c.state,
+			ExpectId:             c.expectedId,
+			ExpectError:          c.expectError,
+			ExpectWarning:        c.expectWarning,
+		}
+		t.Run(fmt.Sprintf("case-%d", i), func(t *testing.T) {
+			r.Apply(t, context.TODO())
+		})
+	}
+}
+
+func TestInstanceTypeDataSourceRead_cheapestWithoutPrice(t *testing.T) {
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			{
+				Method: http.MethodGet,
+				Path:   "/api/clusters/nodes/supported-types",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200,
+					"payload": {
+						"aws": {
+							"worker": [
+								{
+									"id": "worker-type-1",
+									"memory": 16,
+									"cpus": 4
+								},
+								{
+									"id": "worker-type-2",
+									"memory": 32,
+									"cpus": 8
+								}
+							]
+						}
+					}
+				}`,
+			},
+		},
+		Resource:             dataSourceInstanceType(),
+		OperationContextFunc: dataSourceInstanceType().ReadContext,
+		State: map[string]interface{}{
+			"node_type":      api.WorkerNode.String(),
+			"cloud_provider": api.AWS.String(),
+			"strategy":       "cheapest",
+		},
+		ExpectError: "cannot use the cheapest strategy for worker since hopsworks.ai does not report the price of any matching instance type",
+	}
+	r.Apply(t, context.TODO())
+}
@@ -3,6 +3,10 @@ package hopsworksai
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -13,13 +17,25 @@ import (
 
 func dataSourceInstanceTypes() *schema.Resource {
 	return &schema.Resource{
-		Description: "Use this data source to get all the supported instance types for head, worker, and RonDB nodes.",
+		Description: "Use this data source to get all the supported instance types for head, worker, and RonDB nodes, optionally filtered and sorted.",
 		Schema: map[string]*schema.Schema{
 			"node_type": {
 				Description:  "The node type that you want to get its supported instance types.",
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
 				ValidateFunc: validation.StringInSlice(api.GetAllNodeTypes(), false),
+				ExactlyOneOf: []string{"node_type", "node_types"},
+			},
+			"node_types": {
+				Description: fmt.Sprintf("The list of node types that you want to get their supported instance types in one call. It has to be a list of these types (%s).", strings.Join(api.GetAllNodeTypes(), ", ")),
+				Type:        schema.TypeList,
+				Optional:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(api.GetAllNodeTypes(), false),
+				},
+				ExactlyOneOf: []string{"node_type", "node_types"},
 			},
 			"cloud_provider": {
 				Description:  "The cloud provider where you plan to create your cluster.",
@@ -32,6 +48,118 @@ func dataSourceInstanceTypes() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 			},
+			"filter": {
+				Description: "Filter the supported instance types.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"min_memory_gb": {
+							Description:  "Filter based on the minimum memory in gigabytes.",
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.FloatAtLeast(0),
+						},
+						"max_memory_gb": {
+							Description:  "Filter based on the maximum memory in gigabytes. The default value -1 means no upper bound.",
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							Default:      -1,
+							ValidateFunc: validation.Any(validation.FloatAtLeast(0), validation.FloatBetween(-1, -1)),
+						},
+						"min_cpus": {
+							Description:  "Filter based on the minimum number of CPU cores.",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"max_cpus": {
+							Description:  "Filter based on the maximum number of CPU cores. The default value -1 means no upper bound.",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      -1,
+							ValidateFunc: validation.Any(validation.IntAtLeast(0), validation.IntInSlice([]int{-1})),
+						},
+						"with_nvme": {
+							Description: "Filter based on the presence of NVMe drives. If set to true, only return the instance types equipped with NVMe drives, and if set to false, only return the instance types without NVMe drives. If not set, the instance types are not filtered based on NVMe drives.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+						},
+						"min_gpus": {
+							Description:  "Filter based on the minimum number of GPUs.",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"max_gpus": {
+							Description:  "Filter based on the maximum number of GPUs. The default value -1 means no upper bound.",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      -1,
+							ValidateFunc: validation.Any(validation.IntAtLeast(0), validation.IntInSlice([]int{-1})),
+						},
+						"gpu_type": {
+							Description:  "Filter based on the GPU type such as nvidia-tesla-t4.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"architecture": {
+							Description:  fmt.Sprintf("Filter based on the CPU architecture. It has to be one of these types (%s).", strings.Join(api.GetAllCPUArchitectures(), ", ")),
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice(api.GetAllCPUArchitectures(), false),
+						},
+						"id_prefix": {
+							Description:  "Filter based on the prefix of the instance type Id such as m5.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"id_regex": {
+							Description:  "Filter based on a regular expression matching the instance type Id. It can be used to select instance families such as ^(m5|r5)d?\\. to match the m5, m5d, r5, and r5d families.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsValidRegExp,
+						},
+						"exclude": {
+							Description: "The list of instance types to exclude. You can use the wildcards * and ? to exclude a family of instance types such as m5d.*.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringIsNotWhiteSpace,
+							},
+						},
+					},
+				},
+			},
+			"sort": {
+				Description: "The keys used to sort the supported instance types, in order of priority. If not set, the instance types are sorted by the number of CPU cores and then by memory.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Description:  fmt.Sprintf("The sort key. It has to be one of these keys (%s). Instance types without a price are always sorted last when sorting by price.", strings.Join(instanceTypeSortKeys(), ", ")),
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(instanceTypeSortKeys(), false),
+						},
+						"order": {
+							Description:  "The sort direction. It has to be one of these directions (asc, desc).",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "asc",
+							ValidateFunc: validation.StringInSlice([]string{"asc", "desc"}, false),
+						},
+					},
+				},
+			},
 			"supported_types": {
 				Description: "The list of supported instance types.",
 				Type:        schema.TypeList,
@@ -43,6 +171,11 @@ func dataSourceInstanceTypes() *schema.Resource {
 							Type:        schema.TypeString,
 							Computed:    true,
 						},
+						"node_type": {
+							Description: "The node type that supports this instance type.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
 						"memory": {
 							Description: "The instance type memory size in gigabytes.",
 							Type:        schema.TypeFloat,
@@ -58,6 +191,26 @@ func dataSourceInstanceTypes() *schema.Resource {
 							Type:        schema.TypeBool,
 							Computed:    true,
 						},
+						"gpus": {
+							Description: "The instance type number of GPUs.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"gpu_type": {
+							Description: "The instance type GPU type.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"architecture": {
+							Description: "The instance type CPU architecture.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"price": {
+							Description: "The instance type hourly price if reported by hopsworks.ai, otherwise 0.",
+							Type:        schema.TypeFloat,
+							Computed:    true,
+						},
 					},
 				},
 			},
@@ -66,27 +219,162 @@ func dataSourceInstanceTypes() *schema.Resource {
 	}
 }
 
+const (
+	instanceTypeSortKeyId           = "id"
+	instanceTypeSortKeyCPUs         = "cpus"
+	instanceTypeSortKeyMemory       = "memory"
+	instanceTypeSortKeyGPUs         = "gpus"
+	instanceTypeSortKeyPrice        = "price"
+	instanceTypeSortKeyMemoryPerCPU = "memory_per_cpu"
+)
+
+func instanceTypeSortKeys() []string {
+	return []string{
+		instanceTypeSortKeyId,
+		instanceTypeSortKeyCPUs,
+		instanceTypeSortKeyMemory,
+		instanceTypeSortKeyGPUs,
+		instanceTypeSortKeyPrice,
+		instanceTypeSortKeyMemoryPerCPU,
+	}
+}
+
+type instanceTypeSortKey struct {
+	key        string
+	descending bool
+}
+
+func expandInstanceTypeSortKeys(d *schema.ResourceData) []instanceTypeSortKey {
+	keys := make([]instanceTypeSortKey, 0)
+	if v, ok := d.GetOk("sort"); ok {
+		for _, e := range v.([]interface{}) {
+			sortKey := e.(map[string]interface{})
+			keys = append(keys, instanceTypeSortKey{
+				key:        sortKey["key"].(string),
+				descending: sortKey["order"].(string) == "desc",
+			})
+		}
+	}
+	return keys
+}
+
+// compareInstanceTypes returns a negative number if a is ordered before b, a positive number if a is ordered after b, and 0 otherwise
+func compareInstanceTypes(a *api.SupportedInstanceType, b *api.SupportedInstanceType, sortKey instanceTypeSortKey) int {
+	cmp := 0
+	switch sortKey.key {
+	case instanceTypeSortKeyId:
+		cmp = strings.Compare(a.Id, b.Id)
+	case instanceTypeSortKeyCPUs:
+		cmp = a.CPUs - b.CPUs
+	case instanceTypeSortKeyMemory:
+		cmp = compareFloats(a.Memory, b.Memory)
+	case instanceTypeSortKeyGPUs:
+		cmp = a.GPUs - b.GPUs
+	case instanceTypeSortKeyPrice:
+		if a.Price == 0 || b.Price == 0 {
+			return compareFloats(b.Price, a.Price)
+		}
+		cmp = compareFloats(a.Price, b.Price)
+	case instanceTypeSortKeyMemoryPerCPU:
+		cmp = compareFloats(a.GetMemoryPerCPU(), b.GetMemoryPerCPU())
+	}
+	if sortKey.descending {
+		return -cmp
+	}
+	return cmp
+}
+
+func compareFloats(a float64, b float64) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
+
+func sortInstanceTypes(instanceTypes api.SupportedInstanceTypeList, sortKeys []instanceTypeSortKey) {
+	instanceTypes.Sort()
+	if len(sortKeys) == 0 {
+		return
+	}
+	sort.SliceStable(instanceTypes, func(i, j int) bool {
+		for _, sortKey := range sortKeys {
+			if cmp := compareInstanceTypes(&instanceTypes[i], &instanceTypes[j], sortKey); cmp != 0 {
+				return cmp < 0
+			}
+		}
+		return false
+	})
+}
+
+func expandInstanceTypesFilter(d *schema.ResourceData) (*instanceTypeFilter, error) {
+	if v, ok := d.GetOk("filter"); !ok || len(v.([]interface{})) == 0 || v.([]interface{})[0] == nil {
+		return nil, nil
+	}
+
+	filter, err := expandInstanceTypeFilter(d, "filter.0.")
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := d.GetOkExists("filter.0.with_nvme"); !ok {
+		filter.withNVMe = nil
+	}
+	filter.idPrefix = d.Get("filter.0.id_prefix").(string)
+	if v, ok := d.GetOk("filter.0.id_regex"); ok {
+		idRegex, err := regexp.Compile(v.(string))
+		if err != nil {
+			return nil, err
+		}
+		filter.idRegex = idRegex
+	}
+	return filter, nil
+}
+
 func dataSourceInstanceTypesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*api.HopsworksAIClient)
 
 	cloud := api.CloudProvider(d.Get("cloud_provider").(string))
 	region := d.Get("region").(string)
+
+	nodeTypes := getStringList(d, "node_types")
+	if v, ok := d.GetOk("node_type"); ok {
+		nodeTypes = []string{v.(string)}
+	}
+
+	filter, err := expandInstanceTypesFilter(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	sortKeys := expandInstanceTypeSortKeys(d)
+
 	supportedTypes, err := api.GetSupportedInstanceTypes(ctx, client, cloud, region)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	nodeType := d.Get("node_type").(string)
-	instanceTypesArr := supportedTypes.GetByNodeType(api.NodeType(nodeType))
+	instanceTypes := make([]map[string]interface{}, 0)
+	for _, nodeType := range nodeTypes {
+		instanceTypesArr := supportedTypes.GetByNodeType(api.NodeType(nodeType))
 
-	if len(instanceTypesArr) == 0 {
-		return diag.Errorf("no instance types available for %s", nodeType)
-	}
+		if len(instanceTypesArr) == 0 {
+			return diag.Errorf("no instance types available for %s", nodeType)
+		}
 
-	instanceTypesArr.Sort()
+		filteredTypes := make(api.SupportedInstanceTypeList, 0, len(instanceTypesArr))
+		for i := range instanceTypesArr {
+			if filter == nil || filter.matches(&instanceTypesArr[i]) {
+				filteredTypes = append(filteredTypes, instanceTypesArr[i])
+			}
+		}
+
+		sortInstanceTypes(filteredTypes, sortKeys)
+		instanceTypes = append(instanceTypes, structure.FlattenSupportedInstanceTypes(api.NodeType(nodeType), filteredTypes)...)
+	}
 
-	d.SetId(fmt.Sprintf("%s-%s", cloud.String(), nodeType))
-	if err := d.Set("supported_types", structure.FlattenSupportedInstanceTypes(instanceTypesArr)); err != nil {
+	d.SetId(strconv.Itoa(schema.HashString(fmt.Sprintf("%s:%s:%s:%v:%v", cloud.String(), region, strings.Join(nodeTypes, ","), d.Get("filter"), d.Get("sort")))))
+	if err := d.Set("supported_types", instanceTypes); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -9,10 +9,13 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/api"
+	apitest "github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/api/test"
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/structure"
 	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/test"
 )
 
@@ -124,91 +127,151 @@ func TestInstanceTypesDataSourceRead(t *testing.T) {
 	for _, c := range []api.CloudProvider{api.AWS, api.AZURE} {
 		testInstanceTypesDataSource(t, c, api.HeadNode, []interface{}{
 			map[string]interface{}{
-				"id":        "head-type-1",
-				"memory":    20.0,
-				"cpus":      10,
-				"with_nvme": false,
+				"id":           "head-type-1",
+				"node_type":    api.HeadNode.String(),
+				"memory":       20.0,
+				"cpus":         10,
+				"with_nvme":    false,
+				"gpus":         0,
+				"gpu_type":     "",
+				"architecture": api.X86_64.String(),
+				"price":        0.0,
 			},
 			map[string]interface{}{
-				"id":        "head-type-2",
-				"memory":    50.0,
-				"cpus":      20,
-				"with_nvme": false,
+				"id":           "head-type-2",
+				"node_type":    api.HeadNode.String(),
+				"memory":       50.0,
+				"cpus":         20,
+				"with_nvme":    false,
+				"gpus":         0,
+				"gpu_type":     "",
+				"architecture": api.X86_64.String(),
+				"price":        0.0,
 			},
 		})
 
 		testInstanceTypesDataSource(t, c, api.WorkerNode, []interface{}{
 			map[string]interface{}{
-				"id":        "worker-type-1",
-				"memory":    20.0,
-				"cpus":      10,
-				"with_nvme": false,
+				"id":           "worker-type-1",
+				"node_type":    api.WorkerNode.String(),
+				"memory":       20.0,
+				"cpus":         10,
+				"with_nvme":    false,
+				"gpus":         0,
+				"gpu_type":     "",
+				"architecture": api.X86_64.String(),
+				"price":        0.0,
 			},
 			map[string]interface{}{
-				"id":        "worker-type-2",
-				"memory":    50.0,
-				"cpus":      20,
-				"with_nvme": false,
+				"id":           "worker-type-2",
+				"node_type":    api.WorkerNode.String(),
+				"memory":       50.0,
+				"cpus":         20,
+				"with_nvme":    false,
+				"gpus":         0,
+				"gpu_type":     "",
+				"architecture": api.X86_64.String(),
+				"price":        0.0,
 			},
 		})
 
 		testInstanceTypesDataSource(t, c, api.RonDBManagementNode, []interface{}{
 			map[string]interface{}{
-				"id":        "mgm-type-2",
-				"memory":    20.0,
-				"cpus":      2,
-				"with_nvme": false,
+				"id":           "mgm-type-2",
+				"node_type":    api.RonDBManagementNode.String(),
+				"memory":       20.0,
+				"cpus":         2,
+				"with_nvme":    false,
+				"gpus":         0,
+				"gpu_type":     "",
+				"architecture": api.X86_64.String(),
+				"price":        0.0,
 			},
 			map[string]interface{}{
-				"id":        "mgm-type-1",
-				"memory":    30.0,
-				"cpus":      16,
-				"with_nvme": false,
+				"id":           "mgm-type-1",
+				"node_type":    api.RonDBManagementNode.String(),
+				"memory":       30.0,
+				"cpus":         16,
+				"with_nvme":    false,
+				"gpus":         0,
+				"gpu_type":     "",
+				"architecture": api.X86_64.String(),
+				"price":        0.0,
 			},
 		})
 
 		testInstanceTypesDataSource(t, c, api.RonDBDataNode, []interface{}{
 			map[string]interface{}{
-				"id":        "ndbd-type-2",
-				"memory":    50.0,
-				"cpus":      8,
-				"with_nvme": false,
+				"id":           "ndbd-type-2",
+				"node_type":    api.RonDBDataNode.String(),
+				"memory":       50.0,
+				"cpus":         8,
+				"with_nvme":    false,
+				"gpus":         0,
+				"gpu_type":     "",
+				"architecture": api.X86_64.String(),
+				"price":        0.0,
 			},
 			map[string]interface{}{
-				"id":        "ndbd-type-1",
-				"memory":    100.0,
-				"cpus":      16,
-				"with_nvme": false,
+				"id":           "ndbd-type-1",
+				"node_type":    api.RonDBDataNode.String(),
+				"memory":       100.0,
+				"cpus":         16,
+				"with_nvme":    false,
+				"gpus":         0,
+				"gpu_type":     "",
+				"architecture": api.X86_64.String(),
+				"price":        0.0,
 			},
 		})
 
 		testInstanceTypesDataSource(t, c, api.RonDBMySQLNode, []interface{}{
 			map[string]interface{}{
-				"id":        "mysql-type-2",
-				"memory":    50.0,
-				"cpus":      8,
-				"with_nvme": false,
+				"id":           "mysql-type-2",
+				"node_type":    api.RonDBMySQLNode.String(),
+				"memory":       50.0,
+				"cpus":         8,
+				"with_nvme":    false,
+				"gpus":         0,
+				"gpu_type":     "",
+				"architecture": api.X86_64.String(),
+				"price":        0.0,
 			},
 			map[string]interface{}{
-				"id":        "mysql-type-1",
-				"memory":    100.0,
-				"cpus":      16,
-				"with_nvme": false,
+				"id":           "mysql-type-1",
+				"node_type":    api.RonDBMySQLNode.String(),
+				"memory":       100.0,
+				"cpus":         16,
+				"with_nvme":    false,
+				"gpus":         0,
+				"gpu_type":     "",
+				"architecture": api.X86_64.String(),
+				"price":        0.0,
 			},
 		})
 
 		testInstanceTypesDataSource(t, c, api.RonDBAPINode, []interface{}{
 			map[string]interface{}{
-				"id":        "api-type-2",
-				"memory":    50.0,
-				"cpus":      8,
-				"with_nvme": false,
+				"id":           "api-type-2",
+				"node_type":    api.RonDBAPINode.String(),
+				"memory":       50.0,
+				"cpus":         8,
+				"with_nvme":    false,
+				"gpus":         0,
+				"gpu_type":     "",
+				"architecture": api.X86_64.String(),
+				"price":        0.0,
 			},
 			map[string]interface{}{
-				"id":        "api-type-1",
-				"memory":    100.0,
-				"cpus":      16,
-				"with_nvme": false,
+				"id":           "api-type-1",
+				"node_type":    api.RonDBAPINode.String(),
+				"memory":       100.0,
+				"cpus":         16,
+				"with_nvme":    false,
+				"gpus":         0,
+				"gpu_type":     "",
+				"architecture": api.X86_64.String(),
+				"price":        0.0,
 			},
 		})
 	}
@@ -314,7 +377,375 @@ func testInstanceTypesDataSource(t *testing.T, cloud api.CloudProvider, nodeType
 		ExpectState: map[string]interface{}{
 			"supported_types": expectedTypes,
 		},
-		ExpectId: cloud.String() + "-" + nodeType.String(),
+		ExpectId: strconv.Itoa(schema.HashString(fmt.Sprintf("%s::%s:[]:[]", cloud.String(), nodeType.String()))),
 	}
 	r.Apply(t, context.TODO())
 }
+
+func TestInstanceTypesDataSourceRead_filterAndSort(t *testing.T) {
+	workerType1 := api.SupportedInstanceType{Id: "m5.xlarge", CPUs: 4, Memory: 16, Price: 0.2}
+	workerType2 := api.SupportedInstanceType{Id: "m5d.2xlarge", CPUs: 8, Memory: 32, WithNVMe: true, Price: 0.45}
+	workerType3 := api.SupportedInstanceType{Id: "r5.2xlarge", CPUs: 8, Memory: 64, Price: 0.5}
+	workerType4 := api.SupportedInstanceType{Id: "m6g.2xlarge", CPUs: 8, Memory: 32, Architecture: api.ARM64, Price: 0.3}
+	workerType5 := api.SupportedInstanceType{Id: "g4dn.4xlarge", CPUs: 16, Memory: 64, GPUs: 1, GPUType: "nvidia-tesla-t4"}
+	headType1 := api.SupportedInstanceType{Id: "m5.2xlarge", CPUs: 8, Memory: 32}
+	headType2 := api.SupportedInstanceType{Id: "m5.4xlarge", CPUs: 16, Memory: 64}
+
+	cases := []struct {
+		state       map[string]interface{}
+		expected    []interface{}
+		expectError string
+	}{
+		{
+			state: map[string]interface{}{
+				"node_type": api.WorkerNode.String(),
+				"filter": []interface{}{
+					map[string]interface{}{
+						"min_cpus":      8,
+						"max_memory_gb": 32,
+					},
+				},
+			},
+			expected: instanceTypesState(api.WorkerNode, workerType4, workerType2),
+		},
+		{
+			state: map[string]interface{}{
+				"node_type": api.WorkerNode.String(),
+				"filter": []interface{}{
+					map[string]interface{}{
+						"min_cpus": 8,
+						"max_gpus": 0,
+					},
+				},
+			},
+			expected: instanceTypesState(api.WorkerNode, workerType4, workerType2, workerType3),
+		},
+		{
+			state: map[string]interface{}{
+				"node_type": api.WorkerNode.String(),
+				"filter": []interface{}{
+					map[string]interface{}{
+						"with_nvme": true,
+					},
+				},
+			},
+			expected: instanceTypesState(api.WorkerNode, workerType2),
+		},
+		{
+			state: map[string]interface{}{
+				"node_type": api.WorkerNode.String(),
+				"filter": []interface{}{
+					map[string]interface{}{
+						"with_nvme": false,
+					},
+				},
+			},
+			expected: instanceTypesState(api.WorkerNode, workerType1, workerType4, workerType3, workerType5),
+		},
+		{
+			state: map[string]interface{}{
+				"node_type": api.WorkerNode.String(),
+				"filter": []interface{}{
+					map[string]interface{}{
+						"architecture": api.ARM64.String(),
+					},
+				},
+			},
+			expected: instanceTypesState(api.WorkerNode, workerType4),
+		},
+		{
+			state: map[string]interface{}{
+				"node_type": api.WorkerNode.String(),
+				"filter": []interface{}{
+					map[string]interface{}{
+						"min_gpus": 1,
+						"gpu_type": "nvidia-tesla-t4",
+					},
+				},
+			},
+			expected: instanceTypesState(api.WorkerNode, workerType5),
+		},
+		{
+			state: map[string]interface{}{
+				"node_type": api.WorkerNode.String(),
+				"filter": []interface{}{
+					map[string]interface{}{
+						"id_prefix": "m5",
+						"exclude":   []interface{}{"m5d.*"},
+					},
+				},
+			},
+			expected: instanceTypesState(api.WorkerNode, workerType1),
+		},
+		{
+			state: map[string]interface{}{
+				"node_type": api.WorkerNode.String(),
+				"filter": []interface{}{
+					map[string]interface{}{
+						"id_regex": `^(m5|r5)d?\.`,
+					},
+				},
+				"sort": []interface{}{
+					map[string]interface{}{
+						"key":   "memory",
+						"order": "desc",
+					},
+					map[string]interface{}{
+						"key":   "id",
+						"order": "asc",
+					},
+				},
+			},
+			expected: instanceTypesState(api.WorkerNode, workerType3, workerType2, workerType1),
+		},
+		{
+			state: map[string]interface{}{
+				"node_type": api.WorkerNode.String(),
+				"sort": []interface{}{
+					map[string]interface{}{
+						"key":   "price",
+						"order": "desc",
+					},
+				},
+			},
+			expected: instanceTypesState(api.WorkerNode, workerType3, workerType2, workerType4, workerType1, workerType5),
+		},
+		{
+			state: map[string]interface{}{
+				"node_type": api.WorkerNode.String(),
+				"sort": []interface{}{
+					map[string]interface{}{
+						"key":   "memory_per_cpu",
+						"order": "desc",
+					},
+				},
+			},
+			expected: instanceTypesState(api.WorkerNode, workerType3, workerType1, workerType4, workerType2, workerType5),
+		},
+		{
+			state: map[string]interface{}{
+				"node_types": []interface{}{api.HeadNode.String(), api.WorkerNode.String()},
+				"filter": []interface{}{
+					map[string]interface{}{
+						"min_cpus": 16,
+					},
+				},
+			},
+			expected: append(instanceTypesState(api.HeadNode, headType2), instanceTypesState(api.WorkerNode, workerType5)...),
+		},
+		{
+			state: map[string]interface{}{
+				"node_types": []interface{}{api.WorkerNode.String(), api.HeadNode.String()},
+				"filter": []interface{}{
+					map[string]interface{}{
+						"id_prefix": "m5.",
+					},
+				},
+			},
+			expected: append(instanceTypesState(api.WorkerNode, workerType1), instanceTypesState(api.HeadNode, headType1, headType2)...),
+		},
+		{
+			state: map[string]interface{}{
+				"node_types": []interface{}{api.HeadNode.String(), api.RonDBDataNode.String()},
+			},
+			expectError: "no instance types available for " + api.RonDBDataNode.String(),
+		},
+		{
+			state: map[string]interface{}{
+				"node_type": api.WorkerNode.String(),
+				"filter": []interface{}{
+					map[string]interface{}{
+						"min_cpus": 16,
+						"max_cpus": 8,
+					},
+				},
+			},
+			expectError: "min_cpus (16) cannot be greater than max_cpus (8)",
+		},
+	}
+
+	for i, c := range cases {
+		c.state["cloud_provider"] = api.AWS.String()
+		r := test.ResourceFixture{
+			HttpOps: []test.Operation{
+				{
+					Method: http.MethodGet,
+					Path:   "/api/clusters/nodes/supported-types",
+					Response: `{
+						"apiVersion": "v1",
+						"status": "ok",
+						"code": 200,
+						"payload": {
+							"aws": {
+								"head": [
+									{
+										"id": "m5.4xlarge",
+										"memory": 64,
+										"cpus": 16
+									},
+									{
+										"id": "m5.2xlarge",
+										"memory": 32,
+										"cpus": 8
+									}
+								],
+								"worker": [
+									{
+										"id": "g4dn.4xlarge",
+										"memory": 64,
+										"cpus": 16,
+										"gpus": 1,
+										"gpuType": "nvidia-tesla-t4"
+									},
+									{
+										"id": "r5.2xlarge",
+										"memory": 64,
+										"cpus": 8,
+										"price": 0.5
+									},
+									{
+										"id": "m6g.2xlarge",
+										"memory": 32,
+										"cpus": 8,
+										"architecture": "arm64",
+										"price": 0.3
+									},
+									{
+										"id": "m5d.2xlarge",
+										"memory": 32,
+										"cpus": 8,
+										"withNVMe": true,
+										"price": 0.45
+									},
+									{
+										"id": "m5.xlarge",
+										"memory": 16,
+										"cpus": 4,
+										"price": 0.2
+									}
+								]
+							}
+						}
+					}`,
+				},
+			},
+			Resource:             dataSourceInstanceTypes(),
+			OperationContextFunc: dataSourceInstanceTypes().ReadContext,
+			State:                c.state,
+			ExpectError:          c.expectError,
+		}
+		if c.expectError == "" {
+			r.ExpectState = map[string]interface{}{
+				"supported_types": c.expected,
+			}
+		}
+		t.Run(fmt.Sprintf("case-%d", i), func(t *testing.T) {
+			r.Apply(t, context.TODO())
+		})
+	}
+}
+
+func instanceTypesState(nodeType api.NodeType, instanceTypes ...api.SupportedInstanceType) []interface{} {
+	state := make([]interface{}, 0)
+	for _, v := range structure.FlattenSupportedInstanceTypes(nodeType, instanceTypes) {
+		state = append(state, v)
+	}
+	return state
+}
+
+func TestInstanceTypesDataSourceRead_id(t *testing.T) {
+	t.Parallel()
+	states := []map[string]interface{}{
+		{
+			"node_type":      api.WorkerNode.String(),
+			"cloud_provider": api.AWS.String(),
+		},
+		{
+			"node_type":      api.WorkerNode.String(),
+			"cloud_provider": api.AWS.String(),
+			"region":         "region-1",
+		},
+		{
+			"node_type":      api.WorkerNode.String(),
+			"cloud_provider": api.AWS.String(),
+			"region":         "region-2",
+		},
+		{
+			"node_type":      api.WorkerNode.String(),
+			"cloud_provider": api.AWS.String(),
+			"region":         "region-1",
+			"filter": []interface{}{
+				map[string]interface{}{
+					"min_cpus": 8,
+				},
+			},
+		},
+		{
+			"node_type":      api.WorkerNode.String(),
+			"cloud_provider": api.AWS.String(),
+			"region":         "region-1",
+			"filter": []interface{}{
+				map[string]interface{}{
+					"min_cpus": 16,
+				},
+			},
+		},
+		{
+			"node_type":      api.WorkerNode.String(),
+			"cloud_provider": api.AWS.String(),
+			"region":         "region-1",
+			"filter": []interface{}{
+				map[string]interface{}{
+					"min_cpus": 16,
+				},
+			},
+			"sort": []interface{}{
+				map[string]interface{}{
+					"key": "price",
+				},
+			},
+		},
+	}
+
+	ids := make(map[string]int)
+	for i, state := range states {
+		for j := 0; j < 2; j++ {
+			r := dataSourceInstanceTypes()
+			d := schema.TestResourceDataRaw(t, r.Schema, state)
+			client := &api.HopsworksAIClient{
+				Client: &apitest.HttpClientFixture{
+					ExpectMethod: http.MethodGet,
+					ExpectPath:   "/api/clusters/nodes/supported-types",
+					ResponseBody: `{
+						"apiVersion": "v1",
+						"status": "ok",
+						"code": 200,
+						"payload":{
+							"aws": {
+								"worker": [
+									{
+										"id": "worker-type-1",
+										"memory": 20,
+										"cpus": 10
+									}
+								]
+							}
+						}
+					}`,
+					ResponseCode: http.StatusOK,
+					T:            t,
+				},
+			}
+			if diags := r.ReadContext(context.TODO(), d, client); diags.HasError() {
+				t.Fatalf("unexpected error %v", diags)
+			}
+			if k, ok := ids[d.Id()]; ok && k != i {
+				t.Fatalf("expected different ids for states %d and %d but got the same id %s", k, i, d.Id())
+			}
+			ids[d.Id()] = i
+		}
+	}
+	if len(ids) != len(states) {
+		t.Fatalf("expected %d ids but got %d", len(states), len(ids))
+	}
+}
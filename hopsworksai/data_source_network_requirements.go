@@ -0,0 +1,346 @@
+package hopsworksai
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/api"
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/structure"
+)
+
+func networkRuleSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The name of the rule.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"description": {
+				Description: "The description of the rule.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"priority": {
+				Description: "The priority of the rule, to be used with azure network security rules.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"protocol": {
+				Description: "The protocol of the rule using the naming of the selected cloud provider.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"from_port": {
+				Description: "The start of the port range.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"to_port": {
+				Description: "The end of the port range.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"port_range": {
+				Description: "The port range as a string, either a single port, a range in the format <from_port>-<to_port>, or * for all ports.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"cidr_blocks": {
+				Description: "The source CIDR blocks for ingress rules or the destination CIDR blocks for egress rules.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"self": {
+				Description: "The rule allows the traffic between the cluster nodes, use the security group itself (AWS) or the network tags of the cluster nodes (GCP) as the source.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func openPortAllowedCidrsSchema(service string) *schema.Schema {
+	return &schema.Schema{
+		Description: fmt.Sprintf("Limit the external access to the %s ports to these CIDR blocks.", service),
+		Type:        schema.TypeList,
+		Optional:    true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.IsCIDR,
+		},
+	}
+}
+
+func dataSourceNetworkRequirements() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get the network rules needed by Hopsworks clusters running in your own network.",
+		Schema: map[string]*schema.Schema{
+			"cloud_provider": {
+				Description:  "The cloud provider where you plan to create your cluster.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{api.AWS.String(), api.AZURE.String(), api.GCP.String()}, false),
+			},
+			"open_ports": {
+				Description: "The ports that you plan to open on your cluster.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem:        clusterSchema()["open_ports"].Elem,
+			},
+			"rondb_enabled": {
+				Description: "Add the rules required by the managed RonDB nodes.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"arrow_flight_with_duckdb": {
+				Description: "Add the rules required to access the ArrowFlight server.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"issue_lets_encrypt_certificate": {
+				Description: "Add the rule required to issue let's encrypt certificates (port 80).",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"allowed_cidrs": {
+				Description: "Limit the external access to these CIDR blocks. If not set, the external access is allowed from anywhere (0.0.0.0/0).",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsCIDR,
+				},
+			},
+			"open_ports_allowed_cidrs": {
+				Description: "Limit the external access to the open ports of a service to these CIDR blocks instead of allowed_cidrs.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"feature_store":        openPortAllowedCidrsSchema("feature store"),
+						"online_feature_store": openPortAllowedCidrsSchema("online feature store"),
+						"kafka":                openPortAllowedCidrsSchema("kafka"),
+						"ssh":                  openPortAllowedCidrsSchema("ssh"),
+					},
+				},
+			},
+			"internal_cidrs": {
+				Description: "The CIDR blocks of the cluster nodes. If not set, the internal rules are marked with self instead.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsCIDR,
+				},
+			},
+			"ingress_rules": {
+				Description: "The ingress rules required by the cluster.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        networkRuleSchema(),
+			},
+			"egress_rules": {
+				Description: "The egress rules required by the cluster.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        networkRuleSchema(),
+			},
+		},
+		ReadContext: dataSourceNetworkRequirementsRead,
+	}
+}
+
+type networkRule struct {
+	name        string
+	description string
+	fromPort    int
+	toPort      int
+	allPorts    bool
+	cidrBlocks  []string
+	self        bool
+}
+
+func networkRuleProtocol(cloud api.CloudProvider, allPorts bool) string {
+	switch cloud {
+	case api.AZURE:
+		if allPorts {
+			return "*"
+		}
+		return "Tcp"
+	case api.GCP:
+		if allPorts {
+			return "all"
+		}
+		return "tcp"
+	}
+	if allPorts {
+		return "-1"
+	}
+	return "tcp"
+}
+
+func flattenNetworkRules(cloud api.CloudProvider, rules []networkRule) []map[string]interface{} {
+	flattened := make([]map[string]interface{}, 0, len(rules))
+	for i, rule := range rules {
+		var portRange string
+		if rule.allPorts {
+			portRange = "*"
+		} else if rule.fromPort == rule.toPort {
+			portRange = strconv.Itoa(rule.fromPort)
+		} else {
+			portRange = fmt.Sprintf("%d-%d", rule.fromPort, rule.toPort)
+		}
+		flattened = append(flattened, map[string]interface{}{
+			"name":        rule.name,
+			"description": rule.description,
+			"priority":    100 + i*10,
+			"protocol":    networkRuleProtocol(cloud, rule.allPorts),
+			"from_port":   rule.fromPort,
+			"to_port":     rule.toPort,
+			"port_range":  portRange,
+			"cidr_blocks": rule.cidrBlocks,
+			"self":        rule.self,
+		})
+	}
+	return flattened
+}
+
+func getStringList(d *schema.ResourceData, key string) []string {
+	list := make([]string, 0)
+	if v, ok := d.GetOk(key); ok {
+		for _, e := range v.([]interface{}) {
+			list = append(list, e.(string))
+		}
+	}
+	return list
+}
+
+func dataSourceNetworkRequirementsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cloud := api.CloudProvider(d.Get("cloud_provider").(string))
+
+	allowedCidrs := getStringList(d, "allowed_cidrs")
+	if len(allowedCidrs) == 0 {
+		allowedCidrs = []string{"0.0.0.0/0"}
+	}
+
+	internalCidrs := getStringList(d, "internal_cidrs")
+	internalSelf := len(internalCidrs) == 0
+	if internalSelf && cloud == api.AZURE {
+		internalCidrs = []string{"VirtualNetwork"}
+	}
+
+	var ports api.ServiceOpenPorts
+	if v, ok := d.GetOk("open_ports"); ok && v.([]interface{})[0] != nil {
+		ports = structure.ExpandPorts(v.([]interface{})[0].(map[string]interface{}))
+	}
+
+	external := func(name string, description string, port int) networkRule {
+		return networkRule{
+			name:        name,
+			description: description,
+			fromPort:    port,
+			toPort:      port,
+			cidrBlocks:  allowedCidrs,
+		}
+	}
+
+	openPort := func(name string, description string, port int, service string) networkRule {
+		rule := external(name, description, port)
+		if portCidrs := getStringList(d, "open_ports_allowed_cidrs.0."+service); len(portCidrs) > 0 {
+			rule.cidrBlocks = portCidrs
+		}
+		return rule
+	}
+
+	ingress := []networkRule{
+		external("hopsworks-https", "Access Hopsworks UI and REST API", 443),
+	}
+	if d.Get("issue_lets_encrypt_certificate").(bool) {
+		ingress = append(ingress, external("hopsworks-http", "Issue let's encrypt certificates", 80))
+	}
+	if ports.FeatureStore {
+		ingress = append(ingress, openPort("hopsworks-hive-metastore", "Access the feature store using the hive metastore", 9083, "feature_store"),
+			openPort("hopsworks-hive-server", "Access the feature store using the hive server", 9085, "feature_store"))
+	}
+	if d.Get("arrow_flight_with_duckdb").(bool) {
+		ingress = append(ingress, external("hopsworks-arrow-flight", "Access the feature store using ArrowFlight", 5005))
+	}
+	if ports.OnlineFeatureStore {
+		ingress = append(ingress, openPort("hopsworks-mysql", "Access the online feature store using MySQL", 3306, "online_feature_store"))
+	}
+	if ports.Kafka {
+		ingress = append(ingress, openPort("hopsworks-kafka", "Access kafka", 9092, "kafka"))
+	}
+	if ports.SSH {
+		ingress = append(ingress, openPort("hopsworks-ssh", "Access the cluster nodes using ssh", 22, "ssh"))
+	}
+
+	ingress = append(ingress, networkRule{
+		name:        "hopsworks-internal",
+		description: "Allow all traffic between the cluster nodes",
+		allPorts:    true,
+		cidrBlocks:  internalCidrs,
+		self:        internalSelf,
+	})
+
+	if d.Get("rondb_enabled").(bool) {
+		for _, r := range []struct {
+			name        string
+			description string
+			port        int
+		}{
+			{"rondb-management", "Allow traffic to the RonDB management server", 1186},
+			{"rondb-data", "Allow traffic to the RonDB data nodes", 11860},
+			{"rondb-mysql", "Allow traffic to the RonDB MySQL servers", 3306},
+		} {
+			ingress = append(ingress, networkRule{
+				name:        r.name,
+				description: r.description,
+				fromPort:    r.port,
+				toPort:      r.port,
+				cidrBlocks:  internalCidrs,
+				self:        internalSelf,
+			})
+		}
+	}
+
+	egress := []networkRule{
+		{
+			name:        "hopsworks-egress",
+			description: "Allow all outbound traffic",
+			allPorts:    true,
+			cidrBlocks:  []string{"0.0.0.0/0"},
+		},
+	}
+
+	ingressRules := flattenNetworkRules(cloud, ingress)
+	egressRules := flattenNetworkRules(cloud, egress)
+
+	ruleNames := make([]string, 0, len(ingressRules))
+	for _, r := range ingressRules {
+		ruleNames = append(ruleNames, fmt.Sprintf("%s:%s:%s", r["name"], r["port_range"], strings.Join(r["cidr_blocks"].([]string), ",")))
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(cloud.String() + strings.Join(ruleNames, ";"))))
+	if err := d.Set("ingress_rules", ingressRules); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("egress_rules", egressRules); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package hopsworksai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/test"
+)
+
+func testNetworkRule(name string, description string, priority int, protocol string, fromPort int, toPort int, portRange string, cidrBlocks []interface{}, self bool) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        name,
+		"description": description,
+		"priority":    priority,
+		"protocol":    protocol,
+		"from_port":   fromPort,
+		"to_port":     toPort,
+		"port_range":  portRange,
+		"cidr_blocks": cidrBlocks,
+		"self":        self,
+	}
+}
+
+func TestNetworkRequirementsRead_AWS(t *testing.T) {
+	allowed := []interface{}{"10.1.0.0/16", "192.168.1.0/24"}
+	r := test.ResourceFixture{
+		Resource:             dataSourceNetworkRequirements(),
+		OperationContextFunc: dataSourceNetworkRequirements().ReadContext,
+		State: map[string]interface{}{
+			"cloud_provider": "AWS",
+			"open_ports": []interface{}{
+				map[string]interface{}{
+					"feature_store":        true,
+					"online_feature_store": true,
+					"kafka":                true,
+					"ssh":                  true,
+				},
+			},
+			"open_ports_allowed_cidrs": []interface{}{
+				map[string]interface{}{
+					"ssh": []interface{}{"10.2.0.0/16"},
+				},
+			},
+			"arrow_flight_with_duckdb": true,
+			"allowed_cidrs":            allowed,
+		},
+		ExpectState: map[string]interface{}{
+			"ingress_rules": []interface{}{
+				testNetworkRule("hopsworks-https", "Access Hopsworks UI and REST API", 100, "tcp", 443, 443, "443", allowed, false),
+				testNetworkRule("hopsworks-http", "Issue let's encrypt certificates", 110, "tcp", 80, 80, "80", allowed, false),
+				testNetworkRule("hopsworks-hive-metastore", "Access the feature store using the hive metastore", 120, "tcp", 9083, 9083, "9083", allowed, false),
+				testNetworkRule("hopsworks-hive-server", "Access the feature store using the hive server", 130, "tcp", 9085, 9085, "9085", allowed, false),
+				testNetworkRule("hopsworks-arrow-flight", "Access the feature store using ArrowFlight", 140, "tcp", 5005, 5005, "5005", allowed, false),
+				testNetworkRule("hopsworks-mysql", "Access the online feature store using MySQL", 150, "tcp", 3306, 3306, "3306", allowed, false),
+				testNetworkRule("hopsworks-kafka", "Access kafka", 160, "tcp", 9092, 9092, "9092", allowed, false),
+				testNetworkRule("hopsworks-ssh", "Access the cluster nodes using ssh", 170, "tcp", 22, 22, "22", []interface{}{"10.2.0.0/16"}, false),
+				testNetworkRule("hopsworks-internal", "Allow all traffic between the cluster nodes", 180, "-1", 0, 0, "*", []interface{}{}, true),
+				testNetworkRule("rondb-management", "Allow traffic to the RonDB management server", 190, "tcp", 1186, 1186, "1186", []interface{}{}, true),
+				testNetworkRule("rondb-data", "Allow traffic to the RonDB data nodes", 200, "tcp", 11860, 11860, "11860", []interface{}{}, true),
+				testNetworkRule("rondb-mysql", "Allow traffic to the RonDB MySQL servers", 210, "tcp", 3306, 3306, "3306", []interface{}{}, true),
+			},
+			"egress_rules": []interface{}{
+				testNetworkRule("hopsworks-egress", "Allow all outbound traffic", 100, "-1", 0, 0, "*", []interface{}{"0.0.0.0/0"}, false),
+			},
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestNetworkRequirementsRead_AZURE(t *testing.T) {
+	r := test.ResourceFixture{
+		Resource:             dataSourceNetworkRequirements(),
+		OperationContextFunc: dataSourceNetworkRequirements().ReadContext,
+		State: map[string]interface{}{
+			"cloud_provider":                 "AZURE",
+			"issue_lets_encrypt_certificate": false,
+			"rondb_enabled":                  false,
+		},
+		ExpectState: map[string]interface{}{
+			"ingress_rules": []interface{}{
+				testNetworkRule("hopsworks-https", "Access Hopsworks UI and REST API", 100, "Tcp", 443, 443, "443", []interface{}{"0.0.0.0/0"}, false),
+				testNetworkRule("hopsworks-internal", "Allow all traffic between the cluster nodes", 110, "*", 0, 0, "*", []interface{}{"VirtualNetwork"}, true),
+			},
+			"egress_rules": []interface{}{
+				testNetworkRule("hopsworks-egress", "Allow all outbound traffic", 100, "*", 0, 0, "*", []interface{}{"0.0.0.0/0"}, false),
+			},
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestNetworkRequirementsRead_GCP(t *testing.T) {
+	internal := []interface{}{"10.0.0.0/16"}
+	r := test.ResourceFixture{
+		Resource:             dataSourceNetworkRequirements(),
+		OperationContextFunc: dataSourceNetworkRequirements().ReadContext,
+		State: map[string]interface{}{
+			"cloud_provider":                 "GCP",
+			"issue_lets_encrypt_certificate": false,
+			"internal_cidrs":                 internal,
+			"open_ports": []interface{}{
+				map[string]interface{}{
+					"kafka": true,
+				},
+			},
+			"open_ports_allowed_cidrs": []interface{}{
+				map[string]interface{}{
+					"kafka": []interface{}{"172.16.0.0/12"},
+				},
+			},
+		},
+		ExpectState: map[string]interface{}{
+			"ingress_rules": []interface{}{
+				testNetworkRule("hopsworks-https", "Access Hopsworks UI and REST API", 100, "tcp", 443, 443, "443", []interface{}{"0.0.0.0/0"}, false),
+				testNetworkRule("hopsworks-kafka", "Access kafka", 110, "tcp", 9092, 9092, "9092", []interface{}{"172.16.0.0/12"}, false),
+				testNetworkRule("hopsworks-internal", "Allow all traffic between the cluster nodes", 120, "all", 0, 0, "*", internal, false),
+				testNetworkRule("rondb-management", "Allow traffic to the RonDB management server", 130, "tcp", 1186, 1186, "1186", internal, false),
+				testNetworkRule("rondb-data", "Allow traffic to the RonDB data nodes", 140, "tcp", 11860, 11860, "11860", internal, false),
+				testNetworkRule("rondb-mysql", "Allow traffic to the RonDB MySQL servers", 150, "tcp", 3306, 3306, "3306", internal, false),
+			},
+		},
+	}
+	r.Apply(t, context.TODO())
+}
@@ -0,0 +1,331 @@
+package hopsworksai
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/api"
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/helpers"
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/structure"
+)
+
+// The constants below are rough estimates used to size RonDB, they are meant to give a starting point
+// for the online feature store and not to replace benchmarking the actual workload.
+const (
+	ronDBRowOverheadBytes          = 50
+	ronDBFeatureGroupOverheadBytes = 64 * 1024 * 1024
+	ronDBDataMemoryFraction        = 0.7
+	ronDBDataNodeReadsPerCPU       = 30000
+	ronDBDataNodeWritesPerCPU      = 10000
+	ronDBMySQLNodeReadsPerCPU      = 5000
+	ronDBAPINodeWritesPerCPU       = 20000
+	ronDBMaxNodeGroups             = 8
+	ronDBMaxMySQLNodes             = 4
+	ronDBMaxAPINodes               = 4
+	ronDBDataNodeDiskPerMemory     = 2
+)
+
+const (
+	ronDBAvailabilityBasic   = "basic"
+	ronDBAvailabilityHigh    = "high"
+	ronDBAvailabilityMaximum = "maximum"
+)
+
+func ronDBAvailabilityLevels() []string {
+	return []string{
+		ronDBAvailabilityBasic,
+		ronDBAvailabilityHigh,
+		ronDBAvailabilityMaximum,
+	}
+}
+
+func ronDBReplicationFactor(availability string) int {
+	switch availability {
+	case ronDBAvailabilityBasic:
+		return 1
+	case ronDBAvailabilityMaximum:
+		return 3
+	default:
+		return 2
+	}
+}
+
+func dataSourceRonDBSizing() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get a recommended RonDB topology for the online feature store based on the expected data size and load. The recommendation is based on rough estimates of the RonDB memory overhead and throughput per CPU core, so make sure to benchmark your workload before going to production.",
+		Schema: map[string]*schema.Schema{
+			"cloud_provider": {
+				Description:  "The cloud provider where you plan to create your cluster.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{api.AWS.String(), api.AZURE.String(), api.GCP.String()}, false),
+			},
+			"region": {
+				Description: "The region/location/zone where you plan to create your cluster. In case of GCP you should use the zone name.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"expected_rows": {
+				Description:  "The expected total number of rows stored in the online feature store.",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"row_size_bytes": {
+				Description:  "The average size of a row in bytes.",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"feature_groups": {
+				Description:  "The number of online enabled feature groups.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"read_qps": {
+				Description:  "The expected number of feature vector lookups per second.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"write_qps": {
+				Description:  "The expected number of rows written per second to the online feature store.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"availability": {
+				Description:  fmt.Sprintf("The desired availability. It has to be one of these levels (%s) which correspond to a replication factor of 1, 2, and 3 respectively.", strings.Join(ronDBAvailabilityLevels(), ", ")),
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      ronDBAvailabilityHigh,
+				ValidateFunc: validation.StringInSlice(ronDBAvailabilityLevels(), false),
+			},
+			"rondb": {
+				Description: "The recommended RonDB configuration. It has the same structure as the rondb block of hopsworksai_cluster.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: helpers.GetDataSourceSchemaFromResourceSchema(ronDBSchema().Schema),
+				},
+			},
+			"replication_factor": {
+				Description: "The recommended replication factor.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"node_groups": {
+				Description: "The recommended number of node groups, the number of data nodes is the number of node groups multiplied by the replication factor.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"estimated_data_memory_gb": {
+				Description: "The estimated memory in gigabytes required to store one replica of the data.",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+			"single_node_capable": {
+				Description: "The workload can be handled by the single node RonDB setup where the management node, the data node, and the mysqld services are colocated in a single node. The single node setup is only recommended with the basic availability.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"single_node_instance_type": {
+				Description: "The recommended instance type for the single node RonDB setup, empty if single_node_capable is false.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+		ReadContext: dataSourceRonDBSizingRead,
+	}
+}
+
+type ronDBWorkload struct {
+	dataGB            float64
+	readQPS           int
+	writeQPS          int
+	replicationFactor int
+}
+
+func ceilDiv(a float64, b float64) int {
+	return int(math.Ceil(a / b))
+}
+
+// chooseRonDBDataNode returns the smallest data node instance type and the number of node groups that can hold the data and handle the load
+func chooseRonDBDataNode(instanceTypes api.SupportedInstanceTypeList, workload ronDBWorkload) (*api.SupportedInstanceType, int) {
+	for nodeGroups := 1; nodeGroups <= ronDBMaxNodeGroups; nodeGroups++ {
+		memoryPerNode := workload.dataGB / float64(nodeGroups) / ronDBDataMemoryFraction
+		readsPerNode := float64(workload.readQPS) / float64(nodeGroups*workload.replicationFactor)
+		writesPerNode := float64(workload.writeQPS) / float64(nodeGroups)
+		cpusPerNode := int(math.Ceil(readsPerNode/ronDBDataNodeReadsPerCPU + writesPerNode/ronDBDataNodeWritesPerCPU))
+		for i := range instanceTypes {
+			if instanceTypes[i].Memory >= memoryPerNode && instanceTypes[i].CPUs >= cpusPerNode {
+				return &instanceTypes[i], nodeGroups
+			}
+		}
+	}
+	return nil, 0
+}
+
+// chooseRonDBServiceNode returns the smallest instance type that can handle the required CPUs with at most maxCount nodes
+func chooseRonDBServiceNode(instanceTypes api.SupportedInstanceTypeList, requiredCPUs float64, minCount int, maxCount int) (*api.SupportedInstanceType, int) {
+	for i := range instanceTypes {
+		if instanceTypes[i].CPUs == 0 {
+			continue
+		}
+		count := ceilDiv(requiredCPUs, float64(instanceTypes[i].CPUs))
+		if count <= maxCount {
+			if count < minCount {
+				count = minCount
+			}
+			return &instanceTypes[i], count
+		}
+	}
+	largest := &instanceTypes[len(instanceTypes)-1]
+	count := minCount
+	if largest.CPUs > 0 && ceilDiv(requiredCPUs, float64(largest.CPUs)) > count {
+		count = ceilDiv(requiredCPUs, float64(largest.CPUs))
+	}
+	return largest, count
+}
+
+// chooseRonDBSingleNode returns the smallest data node instance type that can run all the RonDB services of the workload in one node
+func chooseRonDBSingleNode(instanceTypes api.SupportedInstanceTypeList, workload ronDBWorkload) *api.SupportedInstanceType {
+	if workload.replicationFactor != 1 {
+		return nil
+	}
+	memory := workload.dataGB / ronDBDataMemoryFraction
+	cpus := int(math.Ceil(float64(workload.readQPS)/ronDBDataNodeReadsPerCPU + float64(workload.writeQPS)/ronDBDataNodeWritesPerCPU + float64(workload.readQPS)/ronDBMySQLNodeReadsPerCPU))
+	for i := range instanceTypes {
+		if instanceTypes[i].Memory >= memory && instanceTypes[i].CPUs >= cpus {
+			return &instanceTypes[i]
+		}
+	}
+	return nil
+}
+
+func dataSourceRonDBSizingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.HopsworksAIClient)
+
+	cloud := api.CloudProvider(d.Get("cloud_provider").(string))
+	region := d.Get("region").(string)
+	supportedTypes, err := api.GetSupportedInstanceTypes(ctx, client, cloud, region)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	nodeTypes := map[api.NodeType]api.SupportedInstanceTypeList{}
+	for _, nodeType := range []api.NodeType{api.RonDBManagementNode, api.RonDBDataNode, api.RonDBMySQLNode, api.RonDBAPINode} {
+		instanceTypes := supportedTypes.GetByNodeType(nodeType)
+		if len(instanceTypes) == 0 {
+			return diag.Errorf("no instance types available for %s", nodeType)
+		}
+		instanceTypes.Sort()
+		nodeTypes[nodeType] = instanceTypes
+	}
+
+	rows := d.Get("expected_rows").(int)
+	rowSize := d.Get("row_size_bytes").(int)
+	featureGroups := d.Get("feature_groups").(int)
+	availability := d.Get("availability").(string)
+	workload := ronDBWorkload{
+		dataGB:            (float64(rows)*float64(rowSize+ronDBRowOverheadBytes) + float64(featureGroups)*ronDBFeatureGroupOverheadBytes) / (1024 * 1024 * 1024),
+		readQPS:           d.Get("read_qps").(int),
+		writeQPS:          d.Get("write_qps").(int),
+		replicationFactor: ronDBReplicationFactor(availability),
+	}
+
+	dataNodeType, nodeGroups := chooseRonDBDataNode(nodeTypes[api.RonDBDataNode], workload)
+	if dataNodeType == nil {
+		return diag.Errorf("no supported data node instance type can hold %.2f GB of data with up to %d node groups, reduce the expected data size or split it across multiple clusters", workload.dataGB, ronDBMaxNodeGroups)
+	}
+
+	minMySQLNodes := 1
+	if workload.replicationFactor > 1 {
+		minMySQLNodes = 2
+	}
+	mysqlNodeType, mysqlNodes := chooseRonDBServiceNode(nodeTypes[api.RonDBMySQLNode], float64(workload.readQPS)/ronDBMySQLNodeReadsPerCPU, minMySQLNodes, ronDBMaxMySQLNodes)
+	apiNodeType, apiNodes := chooseRonDBServiceNode(nodeTypes[api.RonDBAPINode], float64(workload.writeQPS)/ronDBAPINodeWritesPerCPU, 0, ronDBMaxAPINodes)
+
+	defaultRonDB := defaultRonDBConfiguration()
+	dataNodeDiskSize := int(math.Ceil(dataNodeType.Memory * ronDBDataNodeDiskPerMemory))
+	if dataNodeDiskSize < defaultRonDB.DataNodes.DiskSize {
+		dataNodeDiskSize = defaultRonDB.DataNodes.DiskSize
+	}
+
+	ronDB := &api.RonDBConfiguration{
+		AllInOne: false,
+		Configuration: api.RonDBBaseConfiguration{
+			NdbdDefault: api.RonDBNdbdDefaultConfiguration{
+				ReplicationFactor: workload.replicationFactor,
+			},
+			General: defaultRonDB.Configuration.General,
+		},
+		ManagementNodes: api.RonDBNodeConfiguration{
+			NodeConfiguration: api.NodeConfiguration{
+				InstanceType: nodeTypes[api.RonDBManagementNode][0].Id,
+				DiskSize:     defaultRonDB.ManagementNodes.DiskSize,
+			},
+			Count: defaultRonDB.ManagementNodes.Count,
+		},
+		DataNodes: api.RonDBNodeConfiguration{
+			NodeConfiguration: api.NodeConfiguration{
+				InstanceType: dataNodeType.Id,
+				DiskSize:     dataNodeDiskSize,
+			},
+			Count: nodeGroups * workload.replicationFactor,
+		},
+		MYSQLNodes: api.MYSQLNodeConfiguration{
+			RonDBNodeConfiguration: api.RonDBNodeConfiguration{
+				NodeConfiguration: api.NodeConfiguration{
+					InstanceType: mysqlNodeType.Id,
+					DiskSize:     defaultRonDB.MYSQLNodes.DiskSize,
+				},
+				Count: mysqlNodes,
+			},
+			ArrowFlightServer: defaultRonDB.MYSQLNodes.ArrowFlightServer,
+		},
+		APINodes: api.RonDBNodeConfiguration{
+			NodeConfiguration: api.NodeConfiguration{
+				InstanceType: apiNodeType.Id,
+				DiskSize:     defaultRonDB.APINodes.DiskSize,
+			},
+			Count: apiNodes,
+		},
+	}
+
+	singleNodeInstanceType := ""
+	if singleNodeType := chooseRonDBSingleNode(nodeTypes[api.RonDBDataNode], workload); singleNodeType != nil {
+		singleNodeInstanceType = singleNodeType.Id
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(fmt.Sprintf("%s:%s:%d:%d:%d:%d:%d:%s", cloud, region, rows, rowSize, featureGroups, workload.readQPS, workload.writeQPS, availability))))
+	if err := d.Set("rondb", structure.FlattenRonDB(ronDB)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("replication_factor", workload.replicationFactor); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("node_groups", nodeGroups); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("estimated_data_memory_gb", math.Round(workload.dataGB*100)/100); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("single_node_capable", singleNodeInstanceType != ""); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("single_node_instance_type", singleNodeInstanceType); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
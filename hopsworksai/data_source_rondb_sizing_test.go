@@ -0,0 +1,276 @@
+package hopsworksai
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/api"
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/test"
+)
+
+func ronDBSizingSupportedTypesOperation() test.Operation {
+	return test.Operation{
+		Method: http.MethodGet,
+		Path:   "/api/clusters/nodes/supported-types",
+		Response: `{
+			"apiVersion": "v1",
+			"status": "ok",
+			"code": 200,
+			"payload": {
+				"aws": {
+					"head": [],
+					"worker": [],
+					"ronDB": {
+						"mgmd": [
+							{
+								"id": "t3a.medium",
+								"memory": 4,
+								"cpus": 2
+							}
+						],
+						"ndbd": [
+							{
+								"id": "r5.2xlarge",
+								"memory": 64,
+								"cpus": 8
+							},
+							{
+								"id": "r5.large",
+								"memory": 16,
+								"cpus": 2
+							},
+							{
+								"id": "r5.xlarge",
+								"memory": 32,
+								"cpus": 4
+							}
+						],
+						"mysqld": [
+							{
+								"id": "c5.2xlarge",
+								"memory": 16,
+								"cpus": 8
+							},
+							{
+								"id": "c5.xlarge",
+								"memory": 8,
+								"cpus": 4
+							}
+						],
+						"api": [
+							{
+								"id": "c5.xlarge",
+								"memory": 8,
+								"cpus": 4
+							}
+						]
+					}
+				}
+			}
+		}`,
+	}
+}
+
+func ronDBSizingState(replicationFactor int, dataNodeType string, dataNodes int, dataNodeDiskSize int, mysqlNodeType string, mysqlNodes int, apiNodes int) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"configuration": []interface{}{
+				map[string]interface{}{
+					"ndbd_default": []interface{}{
+						map[string]interface{}{
+							"replication_factor": replicationFactor,
+						},
+					},
+					"general": []interface{}{
+						map[string]interface{}{
+							"benchmark": []interface{}{
+								map[string]interface{}{
+									"grant_user_privileges": false,
+								},
+							},
+						},
+					},
+				},
+			},
+			"management_nodes": []interface{}{
+				map[string]interface{}{
+					"instance_type": "t3a.medium",
+					"disk_size":     30,
+					"count":         1,
+					"private_ips":   []interface{}{},
+				},
+			},
+			"data_nodes": []interface{}{
+				map[string]interface{}{
+					"instance_type": dataNodeType,
+					"disk_size":     dataNodeDiskSize,
+					"count":         dataNodes,
+					"private_ips":   []interface{}{},
+				},
+			},
+			"mysql_nodes": []interface{}{
+				map[string]interface{}{
+					"instance_type":            mysqlNodeType,
+					"disk_size":                128,
+					"count":                    mysqlNodes,
+					"arrow_flight_with_duckdb": false,
+					"private_ips":              []interface{}{},
+				},
+			},
+			"api_nodes": []interface{}{
+				map[string]interface{}{
+					"instance_type": "c5.xlarge",
+					"disk_size":     30,
+					"count":         apiNodes,
+					"private_ips":   []interface{}{},
+				},
+			},
+			"single_node": []interface{}{},
+		},
+	}
+}
+
+func TestRonDBSizingDataSourceRead_defaults(t *testing.T) {
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			ronDBSizingSupportedTypesOperation(),
+		},
+		Resource:             dataSourceRonDBSizing(),
+		OperationContextFunc: dataSourceRonDBSizing().ReadContext,
+		State: map[string]interface{}{
+			"cloud_provider": api.AWS.String(),
+			"region":         "us-east-2",
+			"expected_rows":  1000000,
+			"row_size_bytes": 100,
+		},
+		ExpectState: map[string]interface{}{
+			"rondb":                     ronDBSizingState(2, "r5.large", 2, 512, "c5.xlarge", 2, 0),
+			"replication_factor":        2,
+			"node_groups":               1,
+			"estimated_data_memory_gb":  0.2,
+			"single_node_capable":       false,
+			"single_node_instance_type": "",
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestRonDBSizingDataSourceRead_load(t *testing.T) {
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			ronDBSizingSupportedTypesOperation(),
+		},
+		Resource:             dataSourceRonDBSizing(),
+		OperationContextFunc: dataSourceRonDBSizing().ReadContext,
+		State: map[string]interface{}{
+			"cloud_provider": api.AWS.String(),
+			"region":         "us-east-2",
+			"expected_rows":  200000000,
+			"row_size_bytes": 100,
+			"read_qps":       60000,
+			"write_qps":      20000,
+			"availability":   "basic",
+		},
+		ExpectState: map[string]interface{}{
+			"rondb":                     ronDBSizingState(1, "r5.2xlarge", 1, 512, "c5.xlarge", 3, 1),
+			"replication_factor":        1,
+			"node_groups":               1,
+			"estimated_data_memory_gb":  28.0,
+			"single_node_capable":       false,
+			"single_node_instance_type": "",
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestRonDBSizingDataSourceRead_multipleNodeGroups(t *testing.T) {
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			ronDBSizingSupportedTypesOperation(),
+		},
+		Resource:             dataSourceRonDBSizing(),
+		OperationContextFunc: dataSourceRonDBSizing().ReadContext,
+		State: map[string]interface{}{
+			"cloud_provider": api.AWS.String(),
+			"region":         "us-east-2",
+			"expected_rows":  400000000,
+			"row_size_bytes": 100,
+			"feature_groups": 16,
+			"read_qps":       80000,
+			"availability":   "maximum",
+		},
+		ExpectState: map[string]interface{}{
+			"rondb":                     ronDBSizingState(3, "r5.2xlarge", 6, 512, "c5.xlarge", 4, 0),
+			"replication_factor":        3,
+			"node_groups":               2,
+			"estimated_data_memory_gb":  56.88,
+			"single_node_capable":       false,
+			"single_node_instance_type": "",
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestRonDBSizingDataSourceRead_singleNode(t *testing.T) {
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			ronDBSizingSupportedTypesOperation(),
+		},
+		Resource:             dataSourceRonDBSizing(),
+		OperationContextFunc: dataSourceRonDBSizing().ReadContext,
+		State: map[string]interface{}{
+			"cloud_provider": api.AWS.String(),
+			"region":         "us-east-2",
+			"expected_rows":  1000000,
+			"row_size_bytes": 100,
+			"read_qps":       10000,
+			"availability":   "basic",
+		},
+		ExpectState: map[string]interface{}{
+			"rondb":                     ronDBSizingState(1, "r5.large", 1, 512, "c5.xlarge", 1, 0),
+			"replication_factor":        1,
+			"node_groups":               1,
+			"estimated_data_memory_gb":  0.2,
+			"single_node_capable":       true,
+			"single_node_instance_type": "r5.xlarge",
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestRonDBSizingDataSourceRead_tooLarge(t *testing.T) {
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			ronDBSizingSupportedTypesOperation(),
+		},
+		Resource:             dataSourceRonDBSizing(),
+		OperationContextFunc: dataSourceRonDBSizing().ReadContext,
+		State: map[string]interface{}{
+			"cloud_provider": api.AWS.String(),
+			"region":         "us-east-2",
+			"expected_rows":  1000000000,
+			"row_size_bytes": 1000,
+		},
+		ExpectError: "no supported data node instance type can hold 977.95 GB of data with up to 8 node groups, reduce the expected data size or split it across multiple clusters",
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestRonDBSizingDataSourceRead_missingNodeType(t *testing.T) {
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			costEstimateSupportedTypesOperation(),
+		},
+		Resource:             dataSourceRonDBSizing(),
+		OperationContextFunc: dataSourceRonDBSizing().ReadContext,
+		State: map[string]interface{}{
+			"cloud_provider": api.AWS.String(),
+			"region":         "us-east-2",
+			"expected_rows":  1000000,
+			"row_size_bytes": 100,
+		},
+		ExpectError: "no instance types available for rondb_api",
+	}
+	r.Apply(t, context.TODO())
+}
@@ -0,0 +1,260 @@
+package hopsworksai
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/api"
+)
+
+const (
+	upgradePreflightCheckUpgradePath    = "upgrade_path"
+	upgradePreflightCheckDockerRegistry = "docker_registry"
+	upgradePreflightCheckOSRegion       = "os_region"
+	upgradePreflightCheckBackup         = "backup"
+)
+
+func dataSourceUpgradePreflight() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to check whether a cluster can be upgraded to a target version before changing the version of the cluster.",
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Description: "The id of the cluster to upgrade.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"target_version": {
+				Description: "The version to upgrade the cluster to.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"backup_max_age_hours": {
+				Description:  "The maximum age in hours of the latest successful backup of the cluster for the backup check to pass.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      24,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"current_version": {
+				Description: "The current version of the cluster.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"checks": {
+				Description: fmt.Sprintf("The list of preflight checks. The checks are %s (the target version is upgradeable from the current version), %s (the docker registry account required for the upgrade can be resolved), %s (the target version supports the os and region of the cluster), and %s (the cluster has a recent successful backup).", upgradePreflightCheckUpgradePath, upgradePreflightCheckDockerRegistry, upgradePreflightCheckOSRegion, upgradePreflightCheckBackup),
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "The name of the check.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"passed": {
+							Description: "The check passed.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"message": {
+							Description: "The details of the check result.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"passed": {
+				Description: "All the checks passed.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+		},
+		ReadContext: dataSourceUpgradePreflightRead,
+	}
+}
+
+type upgradePreflightCheck struct {
+	name    string
+	passed  bool
+	message string
+}
+
+func checkUpgradePath(cluster *api.Cluster, targetVersion string, target *api.SupportedVersion) upgradePreflightCheck {
+	check := upgradePreflightCheck{name: upgradePreflightCheckUpgradePath}
+	if target == nil {
+		check.message = fmt.Sprintf("version %s is not supported on %s", targetVersion, cluster.Provider)
+		return check
+	}
+	if !isSameVersion(target.UpgradableFromVersion, cluster.Version) {
+		check.message = fmt.Sprintf("version %s is upgradeable from %s and not from the current version %s", targetVersion, target.UpgradableFromVersion, cluster.Version)
+		return check
+	}
+	check.passed = true
+	check.message = fmt.Sprintf("version %s is upgradeable from the current version %s", targetVersion, cluster.Version)
+	if target.Experimental {
+		check.message += ", note that it is an experimental version"
+	}
+	return check
+}
+
+// isSameVersion compares the versions semantically so that 3.0 and 3.0.0 are the same version
+func isSameVersion(v1 string, v2 string) bool {
+	version1 := getHopsworksVersion(v1)
+	version2 := getHopsworksVersion(v2)
+	if version1 == nil || version2 == nil {
+		return v1 == v2
+	}
+	return version1.Equal(version2)
+}
+
+func checkDockerRegistry(cluster *api.Cluster, targetVersion string) upgradePreflightCheck {
+	check := upgradePreflightCheck{name: upgradePreflightCheckDockerRegistry}
+	if !isDockerRegistryRequiredForUpgrade(targetVersion) || cluster.IsGCPCluster() {
+		check.passed = true
+		check.message = fmt.Sprintf("no docker registry account is required to upgrade to %s", targetVersion)
+		return check
+	}
+	account, err := getDockerRegistryAccountForUpgrade(cluster.Provider, cluster.Version, targetVersion, cluster.AWS.EcrRegistryAccountId, cluster.AWS.InstanceProfileArn, cluster.Azure.AcrRegistryName)
+	if err != nil {
+		check.message = err.Error()
+		return check
+	}
+	if account == "" {
+		check.message = fmt.Sprintf("could not resolve the ecr registry account from the instance profile %s, you need to set attribute ecr_registry_account_id", cluster.AWS.InstanceProfileArn)
+		return check
+	}
+	check.passed = true
+	check.message = fmt.Sprintf("the upgrade uses the docker registry account %s", account)
+	return check
+}
+
+func checkOSRegion(cluster *api.Cluster, targetVersion string, target *api.SupportedVersion) upgradePreflightCheck {
+	check := upgradePreflightCheck{name: upgradePreflightCheckOSRegion}
+	if target == nil {
+		check.message = fmt.Sprintf("version %s is not supported on %s", targetVersion, cluster.Provider)
+		return check
+	}
+
+	os := cluster.OS
+	if os == "" {
+		os = api.Ubuntu
+	}
+	var region string
+	switch cluster.Provider {
+	case api.AWS:
+		region = cluster.AWS.Region
+	case api.AZURE:
+		region = cluster.Azure.Location
+	case api.GCP:
+		region = cluster.GCP.Region
+	}
+
+	var regions []string
+	switch os {
+	case api.Ubuntu:
+		regions = target.Regions.Ubuntu
+	case api.CentOS:
+		regions = target.Regions.CentOS
+	}
+	if !contains(regions, region) && !contains(regions, "ALL") {
+		check.message = fmt.Sprintf("version %s does not support %s on region %s", targetVersion, os, region)
+		return check
+	}
+	check.passed = true
+	check.message = fmt.Sprintf("version %s supports %s on region %s", targetVersion, os, region)
+	return check
+}
+
+func checkBackup(cluster *api.Cluster, backups []api.Backup, maxAge time.Duration, now time.Time) upgradePreflightCheck {
+	check := upgradePreflightCheck{name: upgradePreflightCheckBackup}
+	var latest *api.Backup
+	for i := range backups {
+		if backups[i].ClusterId != cluster.Id || backups[i].State != api.BackupSucceed {
+			continue
+		}
+		if latest == nil || backups[i].CreatedOn > latest.CreatedOn {
+			latest = &backups[i]
+		}
+	}
+	if latest == nil {
+		check.message = fmt.Sprintf("no successful backup found for cluster %s", cluster.Id)
+		return check
+	}
+	createdOn := time.Unix(latest.CreatedOn, 0)
+	if now.Sub(createdOn) > maxAge {
+		check.message = fmt.Sprintf("the latest successful backup %s was created on %s which is older than %s", latest.Id, createdOn.UTC().Format(time.RFC3339), maxAge)
+		return check
+	}
+	check.passed = true
+	check.message = fmt.Sprintf("the latest successful backup %s was created on %s", latest.Id, createdOn.UTC().Format(time.RFC3339))
+	return check
+}
+
+func dataSourceUpgradePreflightRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.HopsworksAIClient)
+
+	clusterId := d.Get("cluster_id").(string)
+	targetVersion := d.Get("target_version").(string)
+	maxAge := time.Duration(d.Get("backup_max_age_hours").(int)) * time.Hour
+
+	cluster, err := api.GetCluster(ctx, client, clusterId)
+	if err != nil {
+		return diag.Errorf("failed to obtain cluster state: %s", err)
+	}
+	if cluster == nil {
+		return diag.Errorf("cluster not found for cluster_id %s", clusterId)
+	}
+
+	supportedVersions, err := api.GetSupportedVersions(ctx, client, cluster.Provider)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var target *api.SupportedVersion
+	for i := range supportedVersions {
+		if isSameVersion(supportedVersions[i].Version, targetVersion) {
+			target = &supportedVersions[i]
+			break
+		}
+	}
+
+	backups, err := api.GetBackups(ctx, client, clusterId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	checks := []upgradePreflightCheck{
+		checkUpgradePath(cluster, targetVersion, target),
+		checkDockerRegistry(cluster, targetVersion),
+		checkOSRegion(cluster, targetVersion, target),
+		checkBackup(cluster, backups, maxAge, time.Now()),
+	}
+
+	passed := true
+	checksFlatten := make([]map[string]interface{}, len(checks))
+	for i, check := range checks {
+		passed = passed && check.passed
+		checksFlatten[i] = map[string]interface{}{
+			"name":    check.name,
+			"passed":  check.passed,
+			"message": check.message,
+		}
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(clusterId + ":" + targetVersion)))
+	if err := d.Set("current_version", cluster.Version); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("checks", checksFlatten); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("passed", passed); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,362 @@
+package hopsworksai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/api"
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/test"
+)
+
+func testUpgradePreflightClusterOperation(cloud api.CloudProvider, cloudAttributes string) test.Operation {
+	return testUpgradePreflightClusterVersionOperation(cloud, "3.0.0", cloudAttributes)
+}
+
+func testUpgradePreflightClusterVersionOperation(cloud api.CloudProvider, clusterVersion string, cloudAttributes string) test.Operation {
+	return test.Operation{
+		Method: http.MethodGet,
+		Path:   "/api/clusters/cluster-id-1",
+		Response: fmt.Sprintf(`{
+			"apiVersion": "v1",
+			"status": "ok",
+			"code": 200,
+			"payload":{
+				"cluster": {
+					"id": "cluster-id-1",
+					"name": "cluster",
+					"state": "running",
+					"version": "%s",
+					"provider": "%s",
+					%s
+				}
+			}
+		}`, clusterVersion, cloud.String(), cloudAttributes),
+	}
+}
+
+func testUpgradePreflightVersionsOperation(cloud api.CloudProvider) test.Operation {
+	return test.Operation{
+		Method: http.MethodGet,
+		Path:   "/api/clusters/hopsworks/versions/" + cloud.String(),
+		Response: `{
+			"apiVersion": "v1",
+			"status": "ok",
+			"code": 200,
+			"payload": {
+				"versions":[
+					{
+						"version": "3.1.0",
+						"upgradableFromVersion": "3.0.0",
+						"default": true,
+						"experimental": false,
+						"regions": {
+							"ubuntu": [
+								"region-1"
+							]
+						}
+					},
+					{
+						"version": "3.2.0",
+						"upgradableFromVersion": "3.1.0",
+						"default": false,
+						"experimental": false,
+						"regions": {
+							"ubuntu": [
+								"ALL"
+							]
+						}
+					}
+				]
+			}
+		}`,
+	}
+}
+
+func testUpgradePreflightBackupsOperation(backups ...string) test.Operation {
+	return test.Operation{
+		Method: http.MethodGet,
+		Path:   "/api/backups",
+		Response: fmt.Sprintf(`{
+			"apiVersion": "v1",
+			"status": "ok",
+			"code": 200,
+			"payload": {
+				"backups": [%s]
+			}
+		}`, strings.Join(backups, ",")),
+	}
+}
+
+func testUpgradePreflightBackup(id string, state api.BackupState, createdOn time.Time) string {
+	return fmt.Sprintf(`{
+		"backupId": "%s",
+		"backupName": "backup-name",
+		"clusterId": "cluster-id-1",
+		"cloudProvider": "AWS",
+		"createdOn": %d,
+		"state": "%s"
+	}`, id, createdOn.Unix(), state.String())
+}
+
+func TestUpgradePreflightDataSourceRead_AWS_passed(t *testing.T) {
+	t.Parallel()
+	createdOn := time.Now().Add(-time.Hour)
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			testUpgradePreflightClusterOperation(api.AWS, `"aws": {
+				"region": "region-1",
+				"instanceProfileArn": "arn:aws:iam::000011112222:instance-profile/my-instance-profile"
+			}`),
+			testUpgradePreflightVersionsOperation(api.AWS),
+			testUpgradePreflightBackupsOperation(
+				testUpgradePreflightBackup("backup-id-1", api.BackupSucceed, time.Now().Add(-48*time.Hour)),
+				testUpgradePreflightBackup("backup-id-2", api.BackupSucceed, createdOn),
+				testUpgradePreflightBackup("backup-id-3", api.BackupFailed, time.Now()),
+			),
+		},
+		Resource:             dataSourceUpgradePreflight(),
+		OperationContextFunc: dataSourceUpgradePreflight().ReadContext,
+		State: map[string]interface{}{
+			"cluster_id":     "cluster-id-1",
+			"target_version": "3.1.0",
+		},
+		ExpectId: strconv.Itoa(schema.HashString("cluster-id-1:3.1.0")),
+		ExpectState: map[string]interface{}{
+			"current_version": "3.0.0",
+			"passed":          true,
+			"checks": []interface{}{
+				map[string]interface{}{
+					"name":    "upgrade_path",
+					"passed":  true,
+					"message": "version 3.1.0 is upgradeable from the current version 3.0.0",
+				},
+				map[string]interface{}{
+					"name":    "docker_registry",
+					"passed":  true,
+					"message": "the upgrade uses the docker registry account 000011112222",
+				},
+				map[string]interface{}{
+					"name":    "os_region",
+					"passed":  true,
+					"message": "version 3.1.0 supports ubuntu on region region-1",
+				},
+				map[string]interface{}{
+					"name":    "backup",
+					"passed":  true,
+					"message": fmt.Sprintf("the latest successful backup backup-id-2 was created on %s", time.Unix(createdOn.Unix(), 0).UTC().Format(time.RFC3339)),
+				},
+			},
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestUpgradePreflightDataSourceRead_AWS_failed(t *testing.T) {
+	t.Parallel()
+	createdOn := time.Now().Add(-48 * time.Hour)
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			testUpgradePreflightClusterOperation(api.AWS, `"os": "centos",
+			"aws": {
+				"region": "region-1"
+			}`),
+			testUpgradePreflightVersionsOperation(api.AWS),
+			testUpgradePreflightBackupsOperation(
+				testUpgradePreflightBackup("backup-id-1", api.BackupSucceed, createdOn),
+				testUpgradePreflightBackup("backup-id-2", api.BackupFailed, time.Now()),
+			),
+		},
+		Resource:             dataSourceUpgradePreflight(),
+		OperationContextFunc: dataSourceUpgradePreflight().ReadContext,
+		State: map[string]interface{}{
+			"cluster_id":           "cluster-id-1",
+			"target_version":       "3.2.0",
+			"backup_max_age_hours": 12,
+		},
+		ExpectState: map[string]interface{}{
+			"current_version": "3.0.0",
+			"passed":          false,
+			"checks": []interface{}{
+				map[string]interface{}{
+					"name":    "upgrade_path",
+					"passed":  false,
+					"message": "version 3.2.0 is upgradeable from 3.1.0 and not from the current version 3.0.0",
+				},
+				map[string]interface{}{
+					"name":    "docker_registry",
+					"passed":  false,
+					"message": "could not resolve the ecr registry account from the instance profile , you need to set attribute ecr_registry_account_id",
+				},
+				map[string]interface{}{
+					"name":    "os_region",
+					"passed":  false,
+					"message": "version 3.2.0 does not support centos on region region-1",
+				},
+				map[string]interface{}{
+					"name":    "backup",
+					"passed":  false,
+					"message": fmt.Sprintf("the latest successful backup backup-id-1 was created on %s which is older than 12h0m0s", time.Unix(createdOn.Unix(), 0).UTC().Format(time.RFC3339)),
+				},
+			},
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestUpgradePreflightDataSourceRead_AZURE(t *testing.T) {
+	t.Parallel()
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			testUpgradePreflightClusterOperation(api.AZURE, `"azure": {
+				"location": "region-2"
+			}`),
+			testUpgradePreflightVersionsOperation(api.AZURE),
+			testUpgradePreflightBackupsOperation(),
+		},
+		Resource:             dataSourceUpgradePreflight(),
+		OperationContextFunc: dataSourceUpgradePreflight().ReadContext,
+		State: map[string]interface{}{
+			"cluster_id":     "cluster-id-1",
+			"target_version": "3.4.0",
+		},
+		ExpectState: map[string]interface{}{
+			"current_version": "3.0.0",
+			"passed":          false,
+			"checks": []interface{}{
+				map[string]interface{}{
+					"name":    "upgrade_path",
+					"passed":  false,
+					"message": "version 3.4.0 is not supported on AZURE",
+				},
+				map[string]interface{}{
+					"name":    "docker_registry",
+					"passed":  false,
+					"message": "To upgrade from 3.0.0 to 3.4.0, you need to create an acr registry and configure it by setting attribute acr_registry_name",
+				},
+				map[string]interface{}{
+					"name":    "os_region",
+					"passed":  false,
+					"message": "version 3.4.0 is not supported on AZURE",
+				},
+				map[string]interface{}{
+					"name":    "backup",
+					"passed":  false,
+					"message": "no successful backup found for cluster cluster-id-1",
+				},
+			},
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestUpgradePreflightDataSourceRead_GCP(t *testing.T) {
+	t.Parallel()
+	createdOn := time.Now()
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			testUpgradePreflightClusterOperation(api.GCP, `"gcp": {
+				"region": "region-1",
+				"zone": "region-1-a"
+			}`),
+			testUpgradePreflightVersionsOperation(api.GCP),
+			testUpgradePreflightBackupsOperation(
+				testUpgradePreflightBackup("backup-id-1", api.BackupSucceed, createdOn),
+			),
+		},
+		Resource:             dataSourceUpgradePreflight(),
+		OperationContextFunc: dataSourceUpgradePreflight().ReadContext,
+		State: map[string]interface{}{
+			"cluster_id":     "cluster-id-1",
+			"target_version": "3.1.0",
+		},
+		ExpectState: map[string]interface{}{
+			"passed": true,
+			"checks": []interface{}{
+				map[string]interface{}{
+					"name":    "upgrade_path",
+					"passed":  true,
+					"message": "version 3.1.0 is upgradeable from the current version 3.0.0",
+				},
+				map[string]interface{}{
+					"name":    "docker_registry",
+					"passed":  true,
+					"message": "no docker registry account is required to upgrade to 3.1.0",
+				},
+				map[string]interface{}{
+					"name":    "os_region",
+					"passed":  true,
+					"message": "version 3.1.0 supports ubuntu on region region-1",
+				},
+				map[string]interface{}{
+					"name":    "backup",
+					"passed":  true,
+					"message": fmt.Sprintf("the latest successful backup backup-id-1 was created on %s", time.Unix(createdOn.Unix(), 0).UTC().Format(time.RFC3339)),
+				},
+			},
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestUpgradePreflightDataSourceRead_equivalentVersion(t *testing.T) {
+	t.Parallel()
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			testUpgradePreflightClusterVersionOperation(api.GCP, "3.0", `"gcp": {
+				"region": "region-1"
+			}`),
+			testUpgradePreflightVersionsOperation(api.GCP),
+			testUpgradePreflightBackupsOperation(),
+		},
+		Resource:             dataSourceUpgradePreflight(),
+		OperationContextFunc: dataSourceUpgradePreflight().ReadContext,
+		State: map[string]interface{}{
+			"cluster_id":     "cluster-id-1",
+			"target_version": "3.1.0",
+		},
+		ExpectState: map[string]interface{}{
+			"current_version": "3.0",
+			"checks": []interface{}{
+				map[string]interface{}{
+					"name":    "upgrade_path",
+					"passed":  true,
+					"message": "version 3.1.0 is upgradeable from the current version 3.0",
+				},
+			},
+		},
+		ExpandStateCheckOnlyArray: "checks",
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestUpgradePreflightDataSourceRead_clusterNotFound(t *testing.T) {
+	t.Parallel()
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			{
+				Method: http.MethodGet,
+				Path:   "/api/clusters/cluster-id-1",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 404,
+					"message": "no cluster"
+				}`,
+			},
+		},
+		Resource:             dataSourceUpgradePreflight(),
+		OperationContextFunc: dataSourceUpgradePreflight().ReadContext,
+		State: map[string]interface{}{
+			"cluster_id":     "cluster-id-1",
+			"target_version": "3.1.0",
+		},
+		ExpectError: "cluster not found for cluster_id cluster-id-1",
+	}
+	r.Apply(t, context.TODO())
+}
@@ -2,7 +2,9 @@ package hopsworksai
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -18,7 +20,7 @@ func dataSourceVersion() *schema.Resource {
 				Description:  "The cloud provider where you plan to create your cluster.",
 				Type:         schema.TypeString,
 				Required:     true,
-				ValidateFunc: validation.StringInSlice([]string{api.AWS.String(), api.AZURE.String()}, false),
+				ValidateFunc: validation.StringInSlice([]string{api.AWS.String(), api.AZURE.String(), api.GCP.String()}, false),
 			},
 			"os": {
 				Description:  "Filter based on the supported os.",
@@ -32,6 +34,12 @@ func dataSourceVersion() *schema.Resource {
 				Optional:     true,
 				RequiredWith: []string{"os"},
 			},
+			"version_constraint": {
+				Description:  "Filter based on a version constraint such as `~> 3.8` or `>= 3.4, < 4.0`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateVersionConstraint,
+			},
 			"default": {
 				Description: "The version is the default version.",
 				Type:        schema.TypeBool,
@@ -50,27 +58,7 @@ func dataSourceVersion() *schema.Resource {
 				Computed:    true,
 				Optional:    true,
 			},
-			"supported_regions": {
-				Description: "The list of supported operating systems per regions.",
-				Type:        schema.TypeList,
-				Computed:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						api.Ubuntu.String(): {
-							Description: "The list of regions that support Ubuntu.",
-							Type:        schema.TypeList,
-							Computed:    true,
-							Elem:        schema.TypeString,
-						},
-						api.CentOS.String(): {
-							Description: "The list of regions that support CentOS.",
-							Type:        schema.TypeList,
-							Computed:    true,
-							Elem:        schema.TypeString,
-						},
-					},
-				},
-			},
+			"supported_regions": versionSupportedRegionsSchema(),
 			"release_notes_url": {
 				Description: "The release notes url for this version.",
 				Type:        schema.TypeString,
@@ -81,6 +69,103 @@ func dataSourceVersion() *schema.Resource {
 	}
 }
 
+func versionSupportedRegionsSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "The list of supported operating systems per regions.",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				api.Ubuntu.String(): {
+					Description: "The list of regions that support Ubuntu.",
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem:        schema.TypeString,
+				},
+				api.CentOS.String(): {
+					Description: "The list of regions that support CentOS.",
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem:        schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+type versionFilter struct {
+	os                     api.OS
+	region                 string
+	isDefault              *bool
+	experimental           *bool
+	upgradeableFromVersion string
+	constraint             version.Constraints
+}
+
+func expandVersionFilter(d *schema.ResourceData) (*versionFilter, error) {
+	filter := &versionFilter{
+		os:     api.OS(d.Get("os").(string)),
+		region: d.Get("region").(string),
+	}
+	if v, ok := d.GetOkExists("default"); ok {
+		isDefault := v.(bool)
+		filter.isDefault = &isDefault
+	}
+	if v, ok := d.GetOkExists("experimental"); ok {
+		experimental := v.(bool)
+		filter.experimental = &experimental
+	}
+	if v, ok := d.GetOk("upgradeable_from_version"); ok {
+		filter.upgradeableFromVersion = v.(string)
+	}
+	if v, ok := d.GetOk("version_constraint"); ok {
+		constraint, err := version.NewConstraint(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid version constraint %s: %s", v.(string), err)
+		}
+		filter.constraint = constraint
+	}
+	return filter, nil
+}
+
+func (f *versionFilter) matches(v *api.SupportedVersion) bool {
+	if f.upgradeableFromVersion != "" && v.UpgradableFromVersion != f.upgradeableFromVersion {
+		return false
+	}
+
+	var regions []string
+	switch f.os {
+	case api.Ubuntu:
+		regions = v.Regions.Ubuntu
+	case api.CentOS:
+		regions = v.Regions.CentOS
+	}
+
+	if f.os != "" && len(regions) == 0 {
+		return false
+	}
+
+	if f.os != "" && f.region != "" && !contains(regions, f.region) && !contains(regions, "ALL") {
+		return false
+	}
+
+	if f.isDefault != nil && v.Default != *f.isDefault {
+		return false
+	}
+
+	if f.experimental != nil && v.Experimental != *f.experimental {
+		return false
+	}
+
+	if f.constraint != nil {
+		ver := getHopsworksVersion(v.Version)
+		if ver == nil || !f.constraint.Check(ver) {
+			return false
+		}
+	}
+	return true
+}
+
 func dataSourceVersionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*api.HopsworksAIClient)
 
@@ -90,64 +175,17 @@ func dataSourceVersionRead(ctx context.Context, d *schema.ResourceData, meta int
 		return diag.FromErr(err)
 	}
 
-	if v, ok := d.GetOk("upgradeable_from_version"); ok {
-		upgradeableFromVersion := v.(string)
-		var filteredVersions []api.SupportedVersion = make([]api.SupportedVersion, 0)
-		for _, version := range supportedVersions {
-			if version.UpgradableFromVersion == upgradeableFromVersion {
-				filteredVersions = append(filteredVersions, version)
-			}
-		}
-		supportedVersions = filteredVersions
+	filter, err := expandVersionFilter(d)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	var chosenVersion *api.SupportedVersion
-
-	filterOS := api.OS(d.Get("os").(string))
-	filterRegion := d.Get("region").(string)
-	filterDefault, filterDefaultOk := d.GetOk("default")
-	filterExperimental, filterExperimentalOk := d.GetOk("experimental")
-
 	for i := len(supportedVersions) - 1; i >= 0; i-- {
-		v := supportedVersions[i]
-		switch filterOS {
-		case api.Ubuntu:
-			if len(v.Regions.Ubuntu) == 0 {
-				continue
-			}
-		case api.CentOS:
-			if len(v.Regions.CentOS) == 0 {
-				continue
-			}
+		if filter.matches(&supportedVersions[i]) {
+			chosenVersion = &supportedVersions[i]
+			break
 		}
-
-		if filterRegion != "" {
-			switch filterOS {
-			case api.Ubuntu:
-				if !contains(v.Regions.Ubuntu, filterRegion) && !contains(v.Regions.Ubuntu, "ALL") {
-					continue
-				}
-			case api.CentOS:
-				if !contains(v.Regions.CentOS, filterRegion) && !contains(v.Regions.CentOS, "ALL") {
-					continue
-				}
-			}
-		}
-
-		if filterDefaultOk {
-			if v.Default != filterDefault.(bool) {
-				continue
-			}
-		}
-
-		if filterExperimentalOk {
-			if v.Experimental != filterExperimental.(bool) {
-				continue
-			}
-		}
-
-		chosenVersion = &v
-		break
 	}
 
 	if chosenVersion == nil {
@@ -91,6 +91,20 @@ func TestVersionDataSourceRead_AZURE_experimental(t *testing.T) {
 		})
 }
 
+func TestVersionDataSourceRead_AWS_experimental_false(t *testing.T) {
+	testVersionDataSourceRead(t, api.AWS,
+		map[string]interface{}{
+			"experimental": false,
+		},
+		"4.0",
+		[]interface{}{
+			map[string]interface{}{
+				"centos": []interface{}{"region-1-d", "region-2-d"},
+				"ubuntu": []interface{}{"ALL"},
+			},
+		})
+}
+
 func TestVersionDataSourceRead_AWS_upgradeableFrom(t *testing.T) {
 	testVersionDataSourceRead(t, api.AWS,
 		map[string]interface{}{
@@ -244,6 +258,54 @@ func TestVersionDataSourceRead_AZURE_os_region_error(t *testing.T) {
 	)
 }
 
+func TestVersionDataSourceRead_GCP_latest(t *testing.T) {
+	testVersionDataSourceRead(t, api.GCP,
+		map[string]interface{}{},
+		"5.0",
+		[]interface{}{
+			map[string]interface{}{
+				"centos": []interface{}{"region-1", "region-2"},
+				"ubuntu": []interface{}{"region-3", "region-4"},
+			},
+		})
+}
+
+func TestVersionDataSourceRead_AWS_version_constraint(t *testing.T) {
+	testVersionDataSourceRead(t, api.AWS,
+		map[string]interface{}{
+			"version_constraint": ">= 2.0, < 4.0",
+		},
+		"3.0",
+		[]interface{}{
+			map[string]interface{}{
+				"centos": []interface{}{"region-1", "region-2"},
+				"ubuntu": []interface{}{"region-3", "region-4"},
+			},
+		})
+}
+
+func TestVersionDataSourceRead_GCP_version_constraint_pessimistic(t *testing.T) {
+	testVersionDataSourceRead(t, api.GCP,
+		map[string]interface{}{
+			"version_constraint": "~> 4.0",
+		},
+		"4.0",
+		[]interface{}{
+			map[string]interface{}{
+				"centos": []interface{}{"region-1-d", "region-2-d"},
+				"ubuntu": []interface{}{"ALL"},
+			},
+		})
+}
+
+func TestVersionDataSourceRead_AWS_version_constraint_error(t *testing.T) {
+	testVersionDataSourceRead_error(t, api.AWS,
+		map[string]interface{}{
+			"version_constraint": ">= 10.0",
+		},
+	)
+}
+
 func TestVersionDataSourceRead_AWS_API_error(t *testing.T) {
 	testVersionDataSourceRead_API_error(t, api.AWS)
 }
@@ -252,94 +314,98 @@ func TestVersionDataSourceRead_AZURE_API_error(t *testing.T) {
 	testVersionDataSourceRead_API_error(t, api.AZURE)
 }
 
+func testSupportedVersionsOperation(cloud api.CloudProvider) test.Operation {
+	return test.Operation{
+		Method: http.MethodGet,
+		Path:   "/api/clusters/hopsworks/versions/" + cloud.String(),
+		Response: `{
+			"apiVersion": "v1",
+			"status": "ok",
+			"code": 200,
+			"payload": {
+				"versions":[
+					{
+						"version": "1.0",
+						"upgradableFromVersion": "N/A",
+						"default": false,
+						"experimental": true,
+						"regions": {
+							"ubuntu": [
+								"region-5",
+								"region-6"
+							]
+						}
+					},
+					{
+						"version": "2.0",
+						"upgradableFromVersion": "1.0",
+						"default": false,
+						"experimental": false,
+						"regions": {
+							"centos": [
+								"region-1",
+								"region-2"
+							]
+						}
+					},
+					{
+						"version": "3.0",
+						"upgradableFromVersion": "2.0",
+						"default": false,
+						"experimental": false,
+						"regions": {
+							"centos": [
+								"region-1",
+								"region-2"
+							],
+							"ubuntu": [
+								"region-3",
+								"region-4"
+							]
+						}
+					},
+					{
+						"version": "4.0",
+						"upgradableFromVersion": "3.0",
+						"default": true,
+						"experimental": false,
+						"regions": {
+							"centos": [
+								"region-1-d",
+								"region-2-d"
+							],
+							"ubuntu": [
+								"ALL"
+							]
+						}
+					},
+					{
+						"version": "5.0",
+						"upgradableFromVersion": "N/A",
+						"default": false,
+						"experimental": true,
+						"regions": {
+							"centos": [
+								"region-1",
+								"region-2"
+							],
+							"ubuntu": [
+								"region-3",
+								"region-4"
+							]
+						}
+					}
+				]
+			}
+		}`,
+	}
+}
+
 func testVersionDataSourceRead(t *testing.T, cloud api.CloudProvider, state map[string]interface{}, expectedId string, expectedRegions []interface{}) {
 	state["cloud_provider"] = cloud.String()
 	r := test.ResourceFixture{
 		HttpOps: []test.Operation{
-			{
-				Method: http.MethodGet,
-				Path:   "/api/clusters/hopsworks/versions/" + cloud.String(),
-				Response: `{
-					"apiVersion": "v1",
-					"status": "ok",
-					"code": 200,
-					"payload": {
-						"versions":[
-							{
-								"version": "1.0",
-								"upgradableFromVersion": "N/A",
-								"default": false,
-								"experimental": true,
-								"regions": {
-									"ubuntu": [
-										"region-5",
-										"region-6"
-									]
-								}
-							},
-							{
-								"version": "2.0",
-								"upgradableFromVersion": "1.0",
-								"default": false,
-								"experimental": false,
-								"regions": {
-									"centos": [
-										"region-1",
-										"region-2"
-									]
-								}
-							},
-							{
-								"version": "3.0",
-								"upgradableFromVersion": "2.0",
-								"default": false,
-								"experimental": false,
-								"regions": {
-									"centos": [
-										"region-1",
-										"region-2"
-									],
-									"ubuntu": [
-										"region-3",
-										"region-4"
-									]
-								}
-							},
-							{
-								"version": "4.0",
-								"upgradableFromVersion": "3.0",
-								"default": true,
-								"experimental": false,
-								"regions": {
-									"centos": [
-										"region-1-d",
-										"region-2-d"
-									],
-									"ubuntu": [
-										"ALL"
-									]
-								}
-							},
-							{
-								"version": "5.0",
-								"upgradableFromVersion": "N/A",
-								"default": false,
-								"experimental": true,
-								"regions": {
-									"centos": [
-										"region-1",
-										"region-2"
-									],
-									"ubuntu": [
-										"region-3",
-										"region-4"
-									]
-								}
-							}
-						]
-					}
-				}`,
-			},
+			testSupportedVersionsOperation(cloud),
 		},
 		Resource:             dataSourceVersion(),
 		OperationContextFunc: dataSourceVersion().ReadContext,
@@ -0,0 +1,138 @@
+package hopsworksai
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/api"
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/structure"
+)
+
+func dataSourceVersions() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get all the supported Hopsworks versions.",
+		Schema: map[string]*schema.Schema{
+			"cloud_provider": {
+				Description:  "The cloud provider where you plan to create your cluster.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{api.AWS.String(), api.AZURE.String(), api.GCP.String()}, false),
+			},
+			"os": {
+				Description:  "Filter based on the supported os.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{api.Ubuntu.String(), api.CentOS.String()}, false),
+			},
+			"region": {
+				Description:  "Filter based on the region.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"os"},
+			},
+			"default": {
+				Description: "Filter based on whether the version is the default version.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"experimental": {
+				Description: "Filter based on whether the version is an experimental version.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"upgradeable_from_version": {
+				Description: "Filter based on the version which is upgradeable to the returned versions.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"version_constraint": {
+				Description:  "Filter based on a version constraint such as `~> 3.8` or `>= 3.4, < 4.0`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateVersionConstraint,
+			},
+			"versions": {
+				Description: "The list of supported versions matching the filters in the same order as returned by Hopsworks.ai, the latest version is the last one.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version": {
+							Description: "The Hopsworks version.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"default": {
+							Description: "The version is the default version.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"experimental": {
+							Description: "The version is an experimental version.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"upgradeable_from_version": {
+							Description: "The version which is upgradeable to this version.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"supported_regions": versionSupportedRegionsSchema(),
+						"release_notes_url": {
+							Description: "The release notes url for this version.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"ids": {
+				Description: "The list of versions matching the filters in the same order as versions.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		ReadContext: dataSourceVersionsRead,
+	}
+}
+
+func dataSourceVersionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.HopsworksAIClient)
+
+	cloud := api.CloudProvider(d.Get("cloud_provider").(string))
+	supportedVersions, err := api.GetSupportedVersions(ctx, client, cloud)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter, err := expandVersionFilter(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	versions := make([]api.SupportedVersion, 0)
+	ids := make([]string, 0)
+	for i := range supportedVersions {
+		if filter.matches(&supportedVersions[i]) {
+			versions = append(versions, supportedVersions[i])
+			ids = append(ids, supportedVersions[i].Version)
+		}
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(fmt.Sprintf("%s:%s", cloud, strings.Join(ids, ",")))))
+	if err := d.Set("versions", structure.FlattenVersions(versions)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
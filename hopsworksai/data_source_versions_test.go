@@ -0,0 +1,112 @@
+package hopsworksai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/api"
+	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/test"
+)
+
+func TestVersionsDataSourceRead_all(t *testing.T) {
+	testVersionsDataSourceRead(t, api.GCP, map[string]interface{}{}, []interface{}{"1.0", "2.0", "3.0", "4.0", "5.0"})
+}
+
+func TestVersionsDataSourceRead_version_constraint(t *testing.T) {
+	testVersionsDataSourceRead(t, api.AWS,
+		map[string]interface{}{
+			"version_constraint": ">= 2.0, < 5.0",
+		},
+		[]interface{}{"2.0", "3.0", "4.0"})
+}
+
+func TestVersionsDataSourceRead_filters(t *testing.T) {
+	testVersionsDataSourceRead(t, api.AZURE,
+		map[string]interface{}{
+			"os":                 "centos",
+			"region":             "region-1",
+			"version_constraint": "> 2.0",
+		},
+		[]interface{}{"3.0", "5.0"})
+}
+
+func TestVersionsDataSourceRead_experimental(t *testing.T) {
+	testVersionsDataSourceRead(t, api.AWS,
+		map[string]interface{}{
+			"experimental": true,
+		},
+		[]interface{}{"1.0", "5.0"})
+}
+
+func TestVersionsDataSourceRead_experimental_false(t *testing.T) {
+	testVersionsDataSourceRead(t, api.AWS,
+		map[string]interface{}{
+			"experimental": false,
+		},
+		[]interface{}{"2.0", "3.0", "4.0"})
+}
+
+func TestVersionsDataSourceRead_default_false(t *testing.T) {
+	testVersionsDataSourceRead(t, api.AWS,
+		map[string]interface{}{
+			"default": false,
+		},
+		[]interface{}{"1.0", "2.0", "3.0", "5.0"})
+}
+
+func TestVersionsDataSourceRead_no_match(t *testing.T) {
+	testVersionsDataSourceRead(t, api.AWS,
+		map[string]interface{}{
+			"version_constraint": ">= 10.0",
+		},
+		[]interface{}{})
+}
+
+func TestVersionsDataSourceRead_metadata(t *testing.T) {
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			testSupportedVersionsOperation(api.GCP),
+		},
+		Resource:             dataSourceVersions(),
+		OperationContextFunc: dataSourceVersions().ReadContext,
+		State: map[string]interface{}{
+			"cloud_provider": api.GCP.String(),
+			"default":        true,
+		},
+		ExpectState: map[string]interface{}{
+			"ids": []interface{}{"4.0"},
+			"versions": []interface{}{
+				map[string]interface{}{
+					"version":                  "4.0",
+					"upgradeable_from_version": "3.0",
+					"default":                  true,
+					"experimental":             false,
+					"supported_regions": []interface{}{
+						map[string]interface{}{
+							"centos": []interface{}{"region-1-d", "region-2-d"},
+							"ubuntu": []interface{}{"ALL"},
+						},
+					},
+					"release_notes_url": "",
+				},
+			},
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func testVersionsDataSourceRead(t *testing.T, cloud api.CloudProvider, state map[string]interface{}, expectedIds []interface{}) {
+	state["cloud_provider"] = cloud.String()
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			testSupportedVersionsOperation(cloud),
+		},
+		Resource:             dataSourceVersions(),
+		OperationContextFunc: dataSourceVersions().ReadContext,
+		State:                state,
+		ExpectState: map[string]interface{}{
+			"ids": expectedIds,
+		},
+	}
+	r.Apply(t, context.TODO())
+}
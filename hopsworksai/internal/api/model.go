@@ -384,11 +384,49 @@ func GetAllNodeTypes() []string {
 	}
 }
 
+type CPUArchitecture string
+
+const (
+	X86_64 CPUArchitecture = "x86_64"
+	ARM64  CPUArchitecture = "arm64"
+)
+
+func (a CPUArchitecture) String() string {
+	return string(a)
+}
+
+func GetAllCPUArchitectures() []string {
+	return []string{
+		X86_64.String(),
+		ARM64.String(),
+	}
+}
+
 type SupportedInstanceType struct {
-	Id       string  `json:"id"`
-	CPUs     int     `json:"cpus"`
-	Memory   float64 `json:"memory"`
-	WithNVMe bool    `json:"withNVMe"`
+	Id           string          `json:"id"`
+	CPUs         int             `json:"cpus"`
+	Memory       float64         `json:"memory"`
+	WithNVMe     bool            `json:"withNVMe"`
+	GPUs         int             `json:"gpus,omitempty"`
+	GPUType      string          `json:"gpuType,omitempty"`
+	Architecture CPUArchitecture `json:"architecture,omitempty"`
+	Price        float64         `json:"price,omitempty"`
+}
+
+// GetArchitecture returns the CPU architecture of the instance type, instance types without an architecture are x86_64
+func (t *SupportedInstanceType) GetArchitecture() CPUArchitecture {
+	if t.Architecture == "" {
+		return X86_64
+	}
+	return t.Architecture
+}
+
+// GetMemoryPerCPU returns the memory in gigabytes available per CPU core
+func (t *SupportedInstanceType) GetMemoryPerCPU() float64 {
+	if t.CPUs == 0 {
+		return 0
+	}
+	return t.Memory / float64(t.CPUs)
 }
 
 type SupportedInstanceTypeList []SupportedInstanceType
@@ -403,6 +441,25 @@ func (l SupportedInstanceTypeList) Sort() {
 	})
 }
 
+// SortByPrice sorts the instance types by their hourly price, instance types without a price are moved to the end
+func (l SupportedInstanceTypeList) SortByPrice() {
+	l.Sort()
+	sort.SliceStable(l, func(i, j int) bool {
+		if l[i].Price == 0 || l[j].Price == 0 {
+			return l[i].Price != 0 && l[j].Price == 0
+		}
+		return l[i].Price < l[j].Price
+	})
+}
+
+// SortByMemoryPerCPU sorts the instance types by their memory per CPU core in descending order
+func (l SupportedInstanceTypeList) SortByMemoryPerCPU() {
+	l.Sort()
+	sort.SliceStable(l, func(i, j int) bool {
+		return l[i].GetMemoryPerCPU() > l[j].GetMemoryPerCPU()
+	})
+}
+
 type SupportedRonDBInstanceTypes struct {
 	ManagementNode SupportedInstanceTypeList `json:"mgmd"`
 	DataNode       SupportedInstanceTypeList `json:"ndbd"`
@@ -256,6 +256,90 @@ func TestSortSupportedNodeTypes(t *testing.T) {
 	}
 }
 
+func TestSortSupportedNodeTypesByPrice(t *testing.T) {
+	input := SupportedInstanceTypeList{
+		{
+			Id:     "node-type-1",
+			CPUs:   8,
+			Memory: 32,
+			Price:  0.5,
+		},
+		{
+			Id:     "node-type-2",
+			CPUs:   2,
+			Memory: 8,
+		},
+		{
+			Id:     "node-type-3",
+			CPUs:   4,
+			Memory: 16,
+			Price:  0.2,
+		},
+		{
+			Id:     "node-type-4",
+			CPUs:   8,
+			Memory: 16,
+			Price:  0.2,
+		},
+	}
+
+	expected := []string{"node-type-3", "node-type-4", "node-type-1", "node-type-2"}
+
+	input.SortByPrice()
+	output := make([]string, 0)
+	for _, v := range input {
+		output = append(output, v.Id)
+	}
+	if !reflect.DeepEqual(expected, output) {
+		t.Fatalf("error while matching:\nexpected %#v \nbut got %#v", expected, output)
+	}
+}
+
+func TestSortSupportedNodeTypesByMemoryPerCPU(t *testing.T) {
+	input := SupportedInstanceTypeList{
+		{
+			Id:     "node-type-1",
+			CPUs:   8,
+			Memory: 32,
+		},
+		{
+			Id:     "node-type-2",
+			CPUs:   2,
+			Memory: 16,
+		},
+		{
+			Id:     "node-type-3",
+			CPUs:   4,
+			Memory: 16,
+		},
+		{
+			Id:     "node-type-4",
+			CPUs:   4,
+			Memory: 64,
+		},
+	}
+
+	expected := []string{"node-type-4", "node-type-2", "node-type-3", "node-type-1"}
+
+	input.SortByMemoryPerCPU()
+	output := make([]string, 0)
+	for _, v := range input {
+		output = append(output, v.Id)
+	}
+	if !reflect.DeepEqual(expected, output) {
+		t.Fatalf("error while matching:\nexpected %#v \nbut got %#v", expected, output)
+	}
+}
+
+func TestSupportedInstanceTypeGetArchitecture(t *testing.T) {
+	if arch := (&SupportedInstanceType{}).GetArchitecture(); arch != X86_64 {
+		t.Fatalf("expected %s but got %s", X86_64, arch)
+	}
+	if arch := (&SupportedInstanceType{Architecture: ARM64}).GetArchitecture(); arch != ARM64 {
+		t.Fatalf("expected %s but got %s", ARM64, arch)
+	}
+}
+
 func TestGetAllNodeTypes(t *testing.T) {
 	expected := []string{
 		HeadNode.String(),
@@ -1,6 +1,10 @@
 package helpers
 
-import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
 
 func GetDataSourceSchemaFromResourceSchema(resourceSchema map[string]*schema.Schema) map[string]*schema.Schema {
 	dataSourceSchema := make(map[string]*schema.Schema, len(resourceSchema))
@@ -27,3 +31,75 @@ func GetDataSourceSchemaFromResourceSchema(resourceSchema map[string]*schema.Sch
 	}
 	return dataSourceSchema
 }
+
+// GetDataSourceInputSchemaFromResourceSchema converts a resource schema into a data source schema that accepts
+// the same configuration blocks. Computed only attributes are removed, and ForceNew and DiffSuppressFunc are dropped
+// since they have no meaning for data sources.
+func GetDataSourceInputSchemaFromResourceSchema(resourceSchema map[string]*schema.Schema) map[string]*schema.Schema {
+	dataSourceSchema := make(map[string]*schema.Schema, len(resourceSchema))
+	for k, v := range resourceSchema {
+		if v.Computed && !v.Optional && !v.Required {
+			continue
+		}
+
+		newSchema := &schema.Schema{
+			Type:          v.Type,
+			Description:   v.Description,
+			Optional:      v.Optional,
+			Required:      v.Required,
+			Computed:      v.Computed,
+			Default:       v.Default,
+			Sensitive:     v.Sensitive,
+			MaxItems:      v.MaxItems,
+			MinItems:      v.MinItems,
+			ConflictsWith: v.ConflictsWith,
+			ExactlyOneOf:  v.ExactlyOneOf,
+			AtLeastOneOf:  v.AtLeastOneOf,
+			RequiredWith:  v.RequiredWith,
+			ValidateFunc:  v.ValidateFunc,
+		}
+
+		if v.Type == schema.TypeSet {
+			newSchema.Set = v.Set
+		}
+
+		if elem, ok := v.Elem.(*schema.Resource); ok {
+			newSchema.Elem = &schema.Resource{
+				Schema: GetDataSourceInputSchemaFromResourceSchema(elem.Schema),
+			}
+		} else {
+			newSchema.Elem = v.Elem
+		}
+
+		dataSourceSchema[k] = newSchema
+	}
+	return dataSourceSchema
+}
+
+// IsForceNewKey reports whether changing the attribute identified by the flatmap key
+// (for example head.0.disk_size) requires replacing the resource. Similar to the SDK,
+// a ForceNew list or set only forces a replacement when its number of items changes.
+func IsForceNewKey(resourceSchema map[string]*schema.Schema, key string) bool {
+	parts := strings.Split(key, ".")
+	current := resourceSchema
+	for i := 0; i < len(parts); i++ {
+		s, ok := current[parts[i]]
+		if !ok {
+			return false
+		}
+		if i == len(parts)-1 || s.Type == schema.TypeMap {
+			return s.ForceNew
+		}
+		if parts[i+1] == "#" {
+			return s.ForceNew
+		}
+		elem, ok := s.Elem.(*schema.Resource)
+		if !ok {
+			return s.ForceNew
+		}
+		current = elem.Schema
+		// skip the list index or the set hash
+		i++
+	}
+	return false
+}
@@ -197,3 +197,164 @@ func TestGetDataSourceSchemaFromResourceSchema(t *testing.T) {
 		}
 	}
 }
+
+func TestGetDataSourceInputSchemaFromResourceSchema(t *testing.T) {
+	input := map[string]*schema.Schema{
+		"cluster_id": {
+			Description: "The Id of the cluster.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"head": {
+			Description: "The configurations of the head node of the cluster.",
+			Type:        schema.TypeList,
+			Required:    true,
+			ForceNew:    true,
+			MaxItems:    1,
+			MinItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"instance_type": {
+						Description: "The instance type of the head node.",
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+					"disk_size": {
+						Description: "The disk size of the head node in units of GB.",
+						Type:        schema.TypeInt,
+						Optional:    true,
+						ForceNew:    true,
+						Default:     512,
+						DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+							return true
+						},
+					},
+					"node_id": {
+						Description: "The corresponding aws/azure instance id of the head node.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"workers": {
+			Description:   "The configurations of worker nodes.",
+			Type:          schema.TypeSet,
+			Optional:      true,
+			Set:           WorkerSetHash,
+			ConflictsWith: []string{"autoscale"},
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"count": {
+						Description: "The number of worker nodes.",
+						Type:        schema.TypeInt,
+						Optional:    true,
+						Default:     1,
+					},
+				},
+			},
+		},
+	}
+
+	output := GetDataSourceInputSchemaFromResourceSchema(input)
+
+	if _, ok := output["cluster_id"]; ok {
+		t.Fatalf("computed attribute cluster_id should be removed")
+	}
+
+	head := output["head"]
+	if !head.Required || head.ForceNew || head.MaxItems != 1 || head.MinItems != 1 {
+		t.Fatalf("unexpected head schema %#v", head)
+	}
+	headSchema := head.Elem.(*schema.Resource).Schema
+	if _, ok := headSchema["node_id"]; ok {
+		t.Fatalf("computed attribute head.node_id should be removed")
+	}
+	if !headSchema["instance_type"].Required {
+		t.Fatalf("head.instance_type should be required")
+	}
+	diskSize := headSchema["disk_size"]
+	if !diskSize.Optional || diskSize.ForceNew || diskSize.DiffSuppressFunc != nil || diskSize.Default != 512 {
+		t.Fatalf("unexpected head.disk_size schema %#v", diskSize)
+	}
+
+	workers := output["workers"]
+	if workers.Set == nil || !reflect.DeepEqual(workers.ConflictsWith, []string{"autoscale"}) {
+		t.Fatalf("unexpected workers schema %#v", workers)
+	}
+	if workers.Elem.(*schema.Resource).Schema["count"].Default != 1 {
+		t.Fatalf("unexpected workers.count schema %#v", workers.Elem.(*schema.Resource).Schema["count"])
+	}
+}
+
+func TestIsForceNewKey(t *testing.T) {
+	resourceSchema := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"version": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"tags": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			ForceNew: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"head": {
+			Type:     schema.TypeList,
+			Required: true,
+			ForceNew: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"instance_type": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"disk_size": {
+						Type:     schema.TypeInt,
+						Optional: true,
+						ForceNew: true,
+					},
+				},
+			},
+		},
+		"workers": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Set:      WorkerSetHash,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"count": {
+						Type:     schema.TypeInt,
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+
+	cases := map[string]bool{
+		"name":                 true,
+		"version":              false,
+		"tags.%":               true,
+		"tags.key1":            true,
+		"head.#":               true,
+		"head.0.instance_type": false,
+		"head.0.disk_size":     true,
+		"workers.#":            false,
+		"workers.1234.count":   false,
+		"unknown":              false,
+	}
+
+	for key, expected := range cases {
+		if output := IsForceNewKey(resourceSchema, key); output != expected {
+			t.Fatalf("error while matching %s:\nexpected %#v \nbut got %#v", key, expected, output)
+		}
+	}
+}
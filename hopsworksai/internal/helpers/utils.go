@@ -15,6 +15,33 @@ func SuppressDiffForNonSetKeys(k, old, new string, d *schema.ResourceData) bool
 	return old != "" && new == "" && !ok
 }
 
+// WildcardMatch reports whether value matches pattern, where * matches any sequence of characters
+// and ? matches a single character, similar to the wildcards supported in IAM policies.
+func WildcardMatch(pattern string, value string) bool {
+	p, v := 0, 0
+	star, match := -1, 0
+	for v < len(value) {
+		if p < len(pattern) && (pattern[p] == '?' || pattern[p] == value[v]) {
+			p++
+			v++
+		} else if p < len(pattern) && pattern[p] == '*' {
+			star = p
+			match = v
+			p++
+		} else if star != -1 {
+			p = star + 1
+			match++
+			v = match
+		} else {
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
 func convertStateArray(states interface{}) []string {
 	statesArr := reflect.ValueOf(states)
 	stringArr := make([]string, statesArr.Len())
@@ -23,6 +23,33 @@ func TestSuppressDiffForNonSetKeys(t *testing.T) {
 	}
 }
 
+func TestWildcardMatch(t *testing.T) {
+	cases := []struct {
+		pattern  string
+		value    string
+		expected bool
+	}{
+		{"*", "*", true},
+		{"*", "arn:aws:s3:::bucket", true},
+		{"s3:*", "s3:putobject", true},
+		{"s3:get*", "s3:putobject", false},
+		{"arn:aws:s3:::bucket/*", "arn:aws:s3:::bucket/*", true},
+		{"arn:aws:s3:::bucket/*", "arn:aws:s3:::bucket", false},
+		{"arn:aws:s3:::*", "arn:aws:s3:::bucket/*", true},
+		{"arn:aws:ecr:*:*:repository/*/base", "arn:aws:ecr:*:*:repository/*/base", true},
+		{"arn:aws:ecr:*:*:repository/*/base", "arn:aws:ecr:us-east-2:*:repository/cluster/base", true},
+		{"ec2:describe?olumes", "ec2:describevolumes", true},
+		{"", "", true},
+		{"", "s3:putobject", false},
+	}
+
+	for _, c := range cases {
+		if output := WildcardMatch(c.pattern, c.value); output != c.expected {
+			t.Fatalf("error while matching %s with %s:\nexpected %#v \nbut got %#v", c.pattern, c.value, c.expected, output)
+		}
+	}
+}
+
 func TestConvertClusterStates(t *testing.T) {
 	cases := []struct {
 		input    []api.ClusterState
@@ -1,6 +1,8 @@
 package structure
 
 import (
+	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -39,7 +41,7 @@ func FlattenCluster(cluster *api.Cluster) map[string]interface{} {
 		"gcp_attributes":                        flattenGCPAttributes(cluster),
 		"open_ports":                            flattenPorts(&cluster.Ports),
 		"tags":                                  flattenTags(cluster.Tags),
-		"rondb":                                 flattenRonDB(cluster.RonDB),
+		"rondb":                                 FlattenRonDB(cluster.RonDB),
 		"autoscale":                             flattenAutoscaleConfiguration(cluster.Autoscale),
 		"init_script":                           cluster.InitScript,
 		"run_init_script_first":                 cluster.RunInitScriptFirst,
@@ -49,6 +51,7 @@ func FlattenCluster(cluster *api.Cluster) map[string]interface{} {
 		"collect_logs":                          cluster.CollectLogs,
 		"cluster_domain_prefix":                 cluster.ClusterDomainPrefix,
 		"custom_hosted_zone":                    cluster.CustomHostedZone,
+		"endpoints":                             flattenEndpoints(cluster),
 	}
 }
 
@@ -254,7 +257,7 @@ func flattenTags(tags []api.ClusterTag) map[string]interface{} {
 	return tagsMap
 }
 
-func flattenRonDB(ronDB *api.RonDBConfiguration) []map[string]interface{} {
+func FlattenRonDB(ronDB *api.RonDBConfiguration) []map[string]interface{} {
 	if ronDB == nil {
 		return nil
 	}
@@ -483,6 +486,64 @@ func flattenUpgradeInProgress(upgradeInProgress *api.UpgradeInProgress) []interf
 	}
 }
 
+func flattenServiceEndpoints(host string, mysqlHost string, featureStore bool, onlineFeatureStore bool, kafka bool, arrowFlight bool) map[string]interface{} {
+	endpoints := map[string]interface{}{
+		"rest_api":                   "",
+		"feature_store_jdbc":         "",
+		"hive_metastore":             "",
+		"online_feature_store_mysql": "",
+		"kafka_bootstrap_servers":    "",
+		"arrow_flight":               "",
+	}
+	if host == "" {
+		return endpoints
+	}
+	endpoints["rest_api"] = fmt.Sprintf("https://%s/hopsworks-api/api", host)
+	if featureStore {
+		endpoints["feature_store_jdbc"] = fmt.Sprintf("jdbc:hive2://%s:9085", host)
+		endpoints["hive_metastore"] = fmt.Sprintf("thrift://%s:9083", host)
+	}
+	if onlineFeatureStore {
+		endpoints["online_feature_store_mysql"] = fmt.Sprintf("%s:3306", mysqlHost)
+	}
+	if kafka {
+		endpoints["kafka_bootstrap_servers"] = fmt.Sprintf("%s:9092", host)
+	}
+	if arrowFlight {
+		endpoints["arrow_flight"] = fmt.Sprintf("grpc+tls://%s:5005", mysqlHost)
+	}
+	return endpoints
+}
+
+func flattenEndpoints(cluster *api.Cluster) []map[string]interface{} {
+	arrowFlight := cluster.RonDB != nil && cluster.RonDB.MYSQLNodes.ArrowFlightServer
+
+	var publicHost string
+	if cluster.PublicIPAttached && cluster.URL != "" {
+		if clusterURL, err := url.Parse(cluster.URL); err == nil {
+			publicHost = clusterURL.Hostname()
+		}
+	}
+
+	privateHost := cluster.ClusterConfiguration.Head.PrivateIp
+	privateMySQLHost := privateHost
+	if cluster.RonDB != nil && len(cluster.RonDB.MYSQLNodes.PrivateIps) > 0 {
+		privateMySQLHost = cluster.RonDB.MYSQLNodes.PrivateIps[0]
+	}
+
+	ports := cluster.Ports
+	return []map[string]interface{}{
+		{
+			"public": []map[string]interface{}{
+				flattenServiceEndpoints(publicHost, publicHost, ports.FeatureStore, ports.OnlineFeatureStore, ports.Kafka, arrowFlight && ports.FeatureStore),
+			},
+			"private": []map[string]interface{}{
+				flattenServiceEndpoints(privateHost, privateMySQLHost, true, true, true, arrowFlight),
+			},
+		},
+	}
+}
+
 func flattenGCPDiskEncryption(diskEncryption *api.GCPDiskEncryption) []map[string]interface{} {
 	if diskEncryption == nil {
 		return []map[string]interface{}{}
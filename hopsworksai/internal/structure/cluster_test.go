@@ -428,7 +428,7 @@ func TestFlattenCluster(t *testing.T) {
 		"gcp_attributes":                        emptyAttributes,
 		"open_ports":                            flattenPorts(&input.Ports),
 		"tags":                                  flattenTags(input.Tags),
-		"rondb":                                 flattenRonDB(input.RonDB),
+		"rondb":                                 FlattenRonDB(input.RonDB),
 		"autoscale":                             flattenAutoscaleConfiguration(input.Autoscale),
 		"init_script":                           input.InitScript,
 		"run_init_script_first":                 input.RunInitScriptFirst,
@@ -438,6 +438,7 @@ func TestFlattenCluster(t *testing.T) {
 		"collect_logs":                          input.CollectLogs,
 		"cluster_domain_prefix":                 input.ClusterDomainPrefix,
 		"custom_hosted_zone":                    input.CustomHostedZone,
+		"endpoints":                             flattenEndpoints(input),
 	}
 
 	for _, cloud := range []api.CloudProvider{api.AWS, api.AZURE, api.GCP} {
@@ -770,6 +771,19 @@ func TestExpandOpenPorts(t *testing.T) {
 	}
 }
 
+func TestExpandFlattenOpenPortsRoundTrip(t *testing.T) {
+	for k := range flattenPorts(&api.ServiceOpenPorts{})[0] {
+		input := flattenPorts(&api.ServiceOpenPorts{})[0]
+		input[k] = true
+
+		expandedPorts := ExpandPorts(input)
+		output := flattenPorts(&expandedPorts)[0]
+		if !reflect.DeepEqual(input, output) {
+			t.Fatalf("error while round tripping %s:\nexpected %#v \nbut got %#v", k, input, output)
+		}
+	}
+}
+
 func TestFlattenTags(t *testing.T) {
 	input := []api.ClusterTag{
 		{
@@ -891,14 +905,14 @@ func TestFlattenRonDB(t *testing.T) {
 		},
 	}
 
-	output := flattenRonDB(input)
+	output := FlattenRonDB(input)
 	if !reflect.DeepEqual(expected, output) {
 		t.Fatalf("error while matching:\nexpected %#v \nbut got %#v", expected, output)
 	}
 }
 
 func TestFlattenRonDB_nil(t *testing.T) {
-	output := flattenRonDB(nil)
+	output := FlattenRonDB(nil)
 	if output != nil {
 		t.Fatalf("error while matching:\nexpected nil \nbut got %#v", output)
 	}
@@ -1504,7 +1518,7 @@ func TestFlattenClusters(t *testing.T) {
 			"gcp_attributes":                 emptyAttributes,
 			"open_ports":                     flattenPorts(&input[0].Ports),
 			"tags":                           flattenTags(input[0].Tags),
-			"rondb":                          flattenRonDB(input[0].RonDB),
+			"rondb":                          FlattenRonDB(input[0].RonDB),
 			"autoscale":                      flattenAutoscaleConfiguration(input[0].Autoscale),
 			"init_script":                    input[0].InitScript,
 		},
@@ -1530,7 +1544,7 @@ func TestFlattenClusters(t *testing.T) {
 			"gcp_attributes":                 emptyAttributes,
 			"open_ports":                     flattenPorts(&input[1].Ports),
 			"tags":                           flattenTags(input[1].Tags),
-			"rondb":                          flattenRonDB(input[1].RonDB),
+			"rondb":                          FlattenRonDB(input[1].RonDB),
 			"autoscale":                      flattenAutoscaleConfiguration(input[1].Autoscale),
 			"init_script":                    input[1].InitScript,
 		},
@@ -1556,7 +1570,7 @@ func TestFlattenClusters(t *testing.T) {
 			"gcp_attributes":                 flattenGCPAttributes(&input[2]),
 			"open_ports":                     flattenPorts(&input[2].Ports),
 			"tags":                           flattenTags(input[2].Tags),
-			"rondb":                          flattenRonDB(input[2].RonDB),
+			"rondb":                          FlattenRonDB(input[2].RonDB),
 			"autoscale":                      flattenAutoscaleConfiguration(input[2].Autoscale),
 			"init_script":                    input[2].InitScript,
 		},
@@ -1606,6 +1620,153 @@ func TestFlattenUpgradeInProgress(t *testing.T) {
 	}
 }
 
+func TestFlattenEndpoints(t *testing.T) {
+	emptyEndpoints := map[string]interface{}{
+		"rest_api":                   "",
+		"feature_store_jdbc":         "",
+		"hive_metastore":             "",
+		"online_feature_store_mysql": "",
+		"kafka_bootstrap_servers":    "",
+		"arrow_flight":               "",
+	}
+
+	cases := []struct {
+		input    *api.Cluster
+		expected []map[string]interface{}
+	}{
+		{
+			input: &api.Cluster{},
+			expected: []map[string]interface{}{
+				{
+					"public":  []map[string]interface{}{emptyEndpoints},
+					"private": []map[string]interface{}{emptyEndpoints},
+				},
+			},
+		},
+		{
+			input: &api.Cluster{
+				URL:              "https://cluster-1.cloud.hopsworks.ai/hopsworks/#!/",
+				PublicIPAttached: true,
+				ClusterConfiguration: api.ClusterConfigurationStatus{
+					Head: api.HeadConfigurationStatus{
+						PrivateIp: "10.0.0.1",
+					},
+				},
+				Ports: api.ServiceOpenPorts{
+					Kafka: true,
+				},
+			},
+			expected: []map[string]interface{}{
+				{
+					"public": []map[string]interface{}{
+						{
+							"rest_api":                   "https://cluster-1.cloud.hopsworks.ai/hopsworks-api/api",
+							"feature_store_jdbc":         "",
+							"hive_metastore":             "",
+							"online_feature_store_mysql": "",
+							"kafka_bootstrap_servers":    "cluster-1.cloud.hopsworks.ai:9092",
+							"arrow_flight":               "",
+						},
+					},
+					"private": []map[string]interface{}{
+						{
+							"rest_api":                   "https://10.0.0.1/hopsworks-api/api",
+							"feature_store_jdbc":         "jdbc:hive2://10.0.0.1:9085",
+							"hive_metastore":             "thrift://10.0.0.1:9083",
+							"online_feature_store_mysql": "10.0.0.1:3306",
+							"kafka_bootstrap_servers":    "10.0.0.1:9092",
+							"arrow_flight":               "",
+						},
+					},
+				},
+			},
+		},
+		{
+			input: &api.Cluster{
+				URL:              "https://cluster-2.cloud.hopsworks.ai/hopsworks/#!/",
+				PublicIPAttached: true,
+				ClusterConfiguration: api.ClusterConfigurationStatus{
+					Head: api.HeadConfigurationStatus{
+						PrivateIp: "10.0.0.1",
+					},
+				},
+				Ports: api.ServiceOpenPorts{
+					FeatureStore:       true,
+					OnlineFeatureStore: true,
+				},
+				RonDB: &api.RonDBConfiguration{
+					MYSQLNodes: api.MYSQLNodeConfiguration{
+						RonDBNodeConfiguration: api.RonDBNodeConfiguration{
+							PrivateIps: []string{"10.0.0.2", "10.0.0.3"},
+						},
+						ArrowFlightServer: true,
+					},
+				},
+			},
+			expected: []map[string]interface{}{
+				{
+					"public": []map[string]interface{}{
+						{
+							"rest_api":                   "https://cluster-2.cloud.hopsworks.ai/hopsworks-api/api",
+							"feature_store_jdbc":         "jdbc:hive2://cluster-2.cloud.hopsworks.ai:9085",
+							"hive_metastore":             "thrift://cluster-2.cloud.hopsworks.ai:9083",
+							"online_feature_store_mysql": "cluster-2.cloud.hopsworks.ai:3306",
+							"kafka_bootstrap_servers":    "",
+							"arrow_flight":               "grpc+tls://cluster-2.cloud.hopsworks.ai:5005",
+						},
+					},
+					"private": []map[string]interface{}{
+						{
+							"rest_api":                   "https://10.0.0.1/hopsworks-api/api",
+							"feature_store_jdbc":         "jdbc:hive2://10.0.0.1:9085",
+							"hive_metastore":             "thrift://10.0.0.1:9083",
+							"online_feature_store_mysql": "10.0.0.2:3306",
+							"kafka_bootstrap_servers":    "10.0.0.1:9092",
+							"arrow_flight":               "grpc+tls://10.0.0.2:5005",
+						},
+					},
+				},
+			},
+		},
+		{
+			input: &api.Cluster{
+				URL:              "https://cluster-3.cloud.hopsworks.ai/hopsworks/#!/",
+				PublicIPAttached: false,
+				ClusterConfiguration: api.ClusterConfigurationStatus{
+					Head: api.HeadConfigurationStatus{
+						PrivateIp: "10.0.0.1",
+					},
+				},
+				Ports: api.ServiceOpenPorts{
+					FeatureStore: true,
+				},
+			},
+			expected: []map[string]interface{}{
+				{
+					"public": []map[string]interface{}{emptyEndpoints},
+					"private": []map[string]interface{}{
+						{
+							"rest_api":                   "https://10.0.0.1/hopsworks-api/api",
+							"feature_store_jdbc":         "jdbc:hive2://10.0.0.1:9085",
+							"hive_metastore":             "thrift://10.0.0.1:9083",
+							"online_feature_store_mysql": "10.0.0.1:3306",
+							"kafka_bootstrap_servers":    "10.0.0.1:9092",
+							"arrow_flight":               "",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for i, c := range cases {
+		output := flattenEndpoints(c.input)
+		if !reflect.DeepEqual(c.expected, output) {
+			t.Fatalf("error while matching[%d]:\nexpected %#v \nbut got %#v", i, c.expected, output)
+		}
+	}
+}
+
 func TestFlattenAWSAttributes_bucketConfiguration(t *testing.T) {
 	input := &api.Cluster{
 		Provider: api.AWS,
@@ -2036,7 +2197,7 @@ func TestFlattenRonDB_single_node(t *testing.T) {
 		},
 	}
 
-	output := flattenRonDB(input)
+	output := FlattenRonDB(input)
 	if !reflect.DeepEqual(expected, output) {
 		t.Fatalf("error while matching:\nexpected %#v \nbut got %#v", expected, output)
 	}
@@ -4,19 +4,24 @@ import (
 	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/api"
 )
 
-func FlattenSupportedInstanceTypes(instanceTypes api.SupportedInstanceTypeList) []map[string]interface{} {
+func FlattenSupportedInstanceTypes(nodeType api.NodeType, instanceTypes api.SupportedInstanceTypeList) []map[string]interface{} {
 	supportedTypes := make([]map[string]interface{}, 0)
 	for _, v := range instanceTypes {
-		supportedTypes = append(supportedTypes, flattenSupportedInstanceType(&v))
+		supportedTypes = append(supportedTypes, flattenSupportedInstanceType(nodeType, &v))
 	}
 	return supportedTypes
 }
 
-func flattenSupportedInstanceType(instanceType *api.SupportedInstanceType) map[string]interface{} {
+func flattenSupportedInstanceType(nodeType api.NodeType, instanceType *api.SupportedInstanceType) map[string]interface{} {
 	return map[string]interface{}{
-		"id":        instanceType.Id,
-		"memory":    instanceType.Memory,
-		"cpus":      instanceType.CPUs,
-		"with_nvme": instanceType.WithNVMe,
+		"id":           instanceType.Id,
+		"node_type":    nodeType.String(),
+		"memory":       instanceType.Memory,
+		"cpus":         instanceType.CPUs,
+		"with_nvme":    instanceType.WithNVMe,
+		"gpus":         instanceType.GPUs,
+		"gpu_type":     instanceType.GPUType,
+		"architecture": instanceType.GetArchitecture().String(),
+		"price":        instanceType.Price,
 	}
 }
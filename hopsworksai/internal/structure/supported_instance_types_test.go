@@ -16,13 +16,18 @@ func TestFlattenSupportedInstanceType(t *testing.T) {
 	}
 
 	expected := map[string]interface{}{
-		"id":        "node-type",
-		"cpus":      10,
-		"memory":    30.0,
-		"with_nvme": false,
+		"id":           "node-type",
+		"node_type":    api.WorkerNode.String(),
+		"cpus":         10,
+		"memory":       30.0,
+		"with_nvme":    false,
+		"gpus":         0,
+		"gpu_type":     "",
+		"architecture": api.X86_64.String(),
+		"price":        0.0,
 	}
 
-	output := flattenSupportedInstanceType(input)
+	output := flattenSupportedInstanceType(api.WorkerNode, input)
 	if !reflect.DeepEqual(expected, output) {
 		t.Fatalf("error while matching:\nexpected %#v \nbut got %#v", expected, output)
 	}
@@ -37,29 +42,43 @@ func TestFlattenSupportedInstanceTypes(t *testing.T) {
 			WithNVMe: false,
 		},
 		{
-			Id:       "node-type-2",
-			CPUs:     5,
-			Memory:   20,
-			WithNVMe: false,
+			Id:           "node-type-2",
+			CPUs:         5,
+			Memory:       20,
+			WithNVMe:     true,
+			GPUs:         1,
+			GPUType:      "nvidia-tesla-t4",
+			Architecture: api.ARM64,
+			Price:        0.5,
 		},
 	}
 
 	expected := []map[string]interface{}{
 		{
-			"id":        "node-type-1",
-			"cpus":      10,
-			"memory":    30.0,
-			"with_nvme": false,
+			"id":           "node-type-1",
+			"node_type":    api.HeadNode.String(),
+			"cpus":         10,
+			"memory":       30.0,
+			"with_nvme":    false,
+			"gpus":         0,
+			"gpu_type":     "",
+			"architecture": api.X86_64.String(),
+			"price":        0.0,
 		},
 		{
-			"id":        "node-type-2",
-			"cpus":      5,
-			"memory":    20.0,
-			"with_nvme": false,
+			"id":           "node-type-2",
+			"node_type":    api.HeadNode.String(),
+			"cpus":         5,
+			"memory":       20.0,
+			"with_nvme":    true,
+			"gpus":         1,
+			"gpu_type":     "nvidia-tesla-t4",
+			"architecture": api.ARM64.String(),
+			"price":        0.5,
 		},
 	}
 
-	output := FlattenSupportedInstanceTypes(input)
+	output := FlattenSupportedInstanceTypes(api.HeadNode, input)
 	if !reflect.DeepEqual(expected, output) {
 		t.Fatalf("error while matching:\nexpected %#v \nbut got %#v", expected, output)
 	}
@@ -16,3 +16,13 @@ func FlattenVersion(version *api.SupportedVersion) map[string]interface{} {
 		"release_notes_url": version.ReleaseNotesUrl,
 	}
 }
+
+func FlattenVersions(versions []api.SupportedVersion) []map[string]interface{} {
+	versionsFlatten := make([]map[string]interface{}, 0, len(versions))
+	for i := range versions {
+		versionFlatten := FlattenVersion(&versions[i])
+		versionFlatten["version"] = versions[i].Version
+		versionsFlatten = append(versionsFlatten, versionFlatten)
+	}
+	return versionsFlatten
+}
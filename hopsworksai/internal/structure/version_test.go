@@ -38,3 +38,66 @@ func TestFlattenVersion(t *testing.T) {
 		t.Fatalf("error while matching:\nexpected %#v \nbut got %#v", expected, output)
 	}
 }
+
+func TestFlattenVersions(t *testing.T) {
+	input := []api.SupportedVersion{
+		{
+			Version:               "version-1",
+			UpgradableFromVersion: "N/A",
+			Default:               false,
+			Experimental:          true,
+			Regions: api.SupportedVersionRegions{
+				Ubuntu: []string{"region-1"},
+			},
+		},
+		{
+			Version:               "version-2",
+			UpgradableFromVersion: "version-1",
+			Default:               true,
+			Experimental:          false,
+			Regions: api.SupportedVersionRegions{
+				Ubuntu: []string{"ALL"},
+				CentOS: []string{"region-2"},
+			},
+			ReleaseNotesUrl: "notes-url",
+		},
+	}
+
+	expected := []map[string]interface{}{
+		{
+			"version":                  "version-1",
+			"upgradeable_from_version": "N/A",
+			"default":                  false,
+			"experimental":             true,
+			"supported_regions": []interface{}{
+				map[string]interface{}{
+					"ubuntu": []string{"region-1"},
+					"centos": []string(nil),
+				},
+			},
+			"release_notes_url": "",
+		},
+		{
+			"version":                  "version-2",
+			"upgradeable_from_version": "version-1",
+			"default":                  true,
+			"experimental":             false,
+			"supported_regions": []interface{}{
+				map[string]interface{}{
+					"ubuntu": []string{"ALL"},
+					"centos": []string{"region-2"},
+				},
+			},
+			"release_notes_url": "notes-url",
+		},
+	}
+
+	output := FlattenVersions(input)
+	if !reflect.DeepEqual(expected, output) {
+		t.Fatalf("error while matching:\nexpected %#v \nbut got %#v", expected, output)
+	}
+
+	if output := FlattenVersions(nil); len(output) != 0 {
+		t.Fatalf("expected an empty list but got %#v", output)
+	}
+}
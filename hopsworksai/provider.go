@@ -87,7 +87,16 @@ func Provider(version string) func() *schema.Provider {
 				"hopsworksai_backups":                                     dataSourceBackups(),
 				"hopsworksai_backup":                                      dataSourceBackup(),
 				"hopsworksai_version":                                     dataSourceVersion(),
+				"hopsworksai_versions":                                    dataSourceVersions(),
+				"hopsworksai_upgrade_preflight":                           dataSourceUpgradePreflight(),
 				"hopsworksai_gcp_service_account_custom_role_permissions": dataSourceGCPServiceAccountCustomRolePermissions(),
+				"hopsworksai_aws_cross_account_role_policy":               dataSourceAWSCrossAccountRolePolicy(),
+				"hopsworksai_aws_policy_check":                            dataSourceAWSPolicyCheck(),
+				"hopsworksai_azure_policy_check":                          dataSourceAzurePolicyCheck(),
+				"hopsworksai_gcp_policy_check":                            dataSourceGCPPolicyCheck(),
+				"hopsworksai_network_requirements":                        dataSourceNetworkRequirements(),
+				"hopsworksai_cluster_cost_estimate":                       dataSourceClusterCostEstimate(),
+				"hopsworksai_rondb_sizing":                                dataSourceRonDBSizing(),
 			},
 			ResourcesMap: map[string]*schema.Resource{
 				"hopsworksai_cluster":             clusterResource(),
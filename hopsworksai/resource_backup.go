@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/api"
 	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/helpers"
@@ -145,44 +146,75 @@ func resourceBackupWaitForCompletion(ctx context.Context, client *api.HopsworksA
 			api.BackupFailed,
 		},
 		timeout,
-		func() (result interface{}, state string, err error) {
-			cluster, err := api.GetCluster(ctx, client, clusterId)
-			if err != nil {
-				return nil, "", err
-			}
-			if cluster == nil {
-				return nil, "", fmt.Errorf("cluster not found for cluster id %s", clusterId)
-			}
+		resourceBackupStateRefreshFunc(ctx, client, backupId, clusterId),
+	)
 
-			if cluster.BackupPipelineInProgress {
-				return &api.Backup{Id: ""}, api.PendingBackup.String(), nil
-			}
+	resp, err := waitUntilRunning.WaitForStateContext(ctx)
+	if err != nil {
+		return err
+	}
 
-			backup, err := api.GetBackup(ctx, client, backupId)
-			if err != nil {
-				return nil, "", err
-			}
-			if backup == nil {
-				return &api.Backup{Id: ""}, "", fmt.Errorf("backup not found for backup id %s", backupId)
-			}
+	backup := resp.(*api.Backup)
+	if backup.State != api.BackupSucceed {
+		return fmt.Errorf("failed while waiting for the backup to reach succeed state: %s", backup.StateMessage)
+	}
+	return nil
+}
 
-			tflog.Debug(ctx, fmt.Sprintf("polled backup state: %s", backup.State))
-			return backup, backup.State.String(), nil
+func resourceBackupWaitForProcessing(ctx context.Context, client *api.HopsworksAIClient, timeout time.Duration, backupId string, clusterId string) error {
+	waitUntilProcessing := helpers.BackupStateChange(
+		[]api.BackupState{
+			api.PendingBackup,
+			api.InitializingBackup,
+		},
+		[]api.BackupState{
+			api.ProcessingBackup,
+			api.BackupSucceed,
+			api.BackupFailed,
 		},
+		timeout,
+		resourceBackupStateRefreshFunc(ctx, client, backupId, clusterId),
 	)
 
-	resp, err := waitUntilRunning.WaitForStateContext(ctx)
+	resp, err := waitUntilProcessing.WaitForStateContext(ctx)
 	if err != nil {
 		return err
 	}
 
 	backup := resp.(*api.Backup)
-	if backup.State != api.BackupSucceed {
-		return fmt.Errorf("failed while waiting for the backup to reach succeed state: %s", backup.StateMessage)
+	if backup.State == api.BackupFailed {
+		return fmt.Errorf("failed while waiting for the backup to start processing: %s", backup.StateMessage)
 	}
 	return nil
 }
 
+func resourceBackupStateRefreshFunc(ctx context.Context, client *api.HopsworksAIClient, backupId string, clusterId string) retry.StateRefreshFunc {
+	return func() (result interface{}, state string, err error) {
+		cluster, err := api.GetCluster(ctx, client, clusterId)
+		if err != nil {
+			return nil, "", err
+		}
+		if cluster == nil {
+			return nil, "", fmt.Errorf("cluster not found for cluster id %s", clusterId)
+		}
+
+		if cluster.BackupPipelineInProgress {
+			return &api.Backup{Id: ""}, api.PendingBackup.String(), nil
+		}
+
+		backup, err := api.GetBackup(ctx, client, backupId)
+		if err != nil {
+			return nil, "", err
+		}
+		if backup == nil {
+			return &api.Backup{Id: ""}, "", fmt.Errorf("backup not found for backup id %s", backupId)
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("polled backup state: %s", backup.State))
+		return backup, backup.State.String(), nil
+	}
+}
+
 func resourceBackupWaitForDeleting(ctx context.Context, client *api.HopsworksAIClient, timeout time.Duration, backupId string) error {
 	waitUntilDeleted := helpers.BackupStateChange(
 		[]api.BackupState{
@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/api"
@@ -18,7 +20,44 @@ import (
 )
 
 func instanceProfileRegex() *regexp.Regexp {
-	return regexp.MustCompile(`^arn:aws:iam::([0-9]*):instance-profile/(.*)$`)
+	return regexp.MustCompile(`^arn:aws(?:-cn|-us-gov)?:iam::([0-9]*):instance-profile/(.*)$`)
+}
+
+func clusterEndpointsSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"rest_api": {
+				Description: "The url of the Hopsworks REST API.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"feature_store_jdbc": {
+				Description: "The JDBC url to access the feature store using the hive server.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"hive_metastore": {
+				Description: "The url of the hive metastore.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"online_feature_store_mysql": {
+				Description: "The MySQL endpoint to access the online feature store.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"kafka_bootstrap_servers": {
+				Description: "The kafka bootstrap servers.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"arrow_flight": {
+				Description: "The ArrowFlight server endpoint, available only if arrow_flight_with_duckdb is enabled.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
 }
 
 func defaultRonDBConfiguration() api.RonDBConfiguration {
@@ -99,6 +138,143 @@ func spotSchema() map[string]*schema.Schema {
 	}
 }
 
+func finalBackupSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Create a backup of the cluster right before it gets destroyed. The cluster is stopped first if it is running, so make sure to increase the delete timeout to account for the time needed to stop and backup the cluster.",
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"enabled": {
+					Description: "Enable or disable creating a backup before destroying the cluster.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+				},
+				"name_prefix": {
+					Description: "The prefix of the backup name, the creation timestamp is appended to it.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "final-backup",
+				},
+				"wait": {
+					Description: "Wait for the backup to succeed before deleting the cluster. If set to false, the cluster deletion is requested as soon as the backup starts processing, and the backup could still fail afterwards.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+				},
+			},
+		},
+	}
+}
+
+func deletionProtectionSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Protect the cluster from being destroyed or replaced by Terraform. You need to set it to false and apply the change before you can destroy the cluster or apply changes that require replacing it. This protection is enforced only by Terraform and it does not prevent deleting the cluster from the Hopsworks.ai console.",
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+	}
+}
+
+const (
+	autoUpgradePolicyPatch = "patch"
+	autoUpgradePolicyMinor = "minor"
+)
+
+func autoUpgradeDays() []string {
+	days := make([]string, 0, 7)
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		days = append(days, strings.ToLower(d.String()))
+	}
+	return days
+}
+
+func autoUpgradeSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Upgrade the cluster automatically to newer non experimental versions that are upgradeable from the current version. The upgrade is proposed in the plan, and only if the plan runs inside the maintenance window, so you still need to apply it. Once the cluster is upgraded, the configured version is treated as the minimum version and it does not cause a rollback of the cluster. Setting version to an older version than the current one is rejected while auto_upgrade is set, unless it rolls back a failed upgrade.",
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"policy": {
+					Description:  fmt.Sprintf("The versions to upgrade to. Use %s to upgrade only to newer patch versions of the same minor version, or %s to upgrade to newer minor and patch versions of the same major version.", autoUpgradePolicyPatch, autoUpgradePolicyMinor),
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice([]string{autoUpgradePolicyPatch, autoUpgradePolicyMinor}, false),
+				},
+				"maintenance_window": {
+					Description: "The time window in UTC during which the upgrade can be proposed. If not set, the upgrade is proposed whenever a newer version is available.",
+					Type:        schema.TypeList,
+					Optional:    true,
+					MaxItems:    1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"days": {
+								Description: fmt.Sprintf("The days of the week on which the maintenance window starts. It has to be a list of (%s). If not set, the maintenance window starts every day.", strings.Join(autoUpgradeDays(), ", ")),
+								Type:        schema.TypeSet,
+								Optional:    true,
+								Elem: &schema.Schema{
+									Type:         schema.TypeString,
+									ValidateFunc: validation.StringInSlice(autoUpgradeDays(), false),
+								},
+							},
+							"start_time": {
+								Description:  "The start time of the maintenance window in UTC using the format HH:MM.",
+								Type:         schema.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringMatch(regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`), "start_time must use the format HH:MM"),
+							},
+							"duration_hours": {
+								Description:  "The duration of the maintenance window in hours.",
+								Type:         schema.TypeInt,
+								Optional:     true,
+								Default:      4,
+								ValidateFunc: validation.IntBetween(1, 24),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func autoUpgradeTargetVersionSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "The version that auto_upgrade upgrades the cluster to. It matches the version of the cluster unless an upgrade is proposed in the plan, applying the change upgrades the cluster to this version.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+}
+
+const (
+	upgradeOnFailureRollback = "rollback"
+	upgradeOnFailureLeave    = "leave"
+)
+
+func upgradeSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "The configuration of the cluster upgrades.",
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"on_failure": {
+					Description:  fmt.Sprintf("The action to take if the upgrade fails. Use %s to roll back the cluster to the version it had before the upgrade and keep that version in the state, or %s to leave the cluster in error state so that you can inspect it and roll back manually by setting version to the previous version. The upgrade is only rolled back if the cluster ends up in an error state, timeouts leave the upgrade running.", upgradeOnFailureRollback, upgradeOnFailureLeave),
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      upgradeOnFailureLeave,
+					ValidateFunc: validation.StringInSlice([]string{upgradeOnFailureRollback, upgradeOnFailureLeave}, false),
+				},
+			},
+		},
+	}
+}
+
 func clusterSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"cluster_id": {
@@ -326,7 +502,7 @@ func clusterSchema() map[string]*schema.Schema {
 			ExactlyOneOf: []string{"aws_attributes", "azure_attributes", "gcp_attributes"},
 		},
 		"open_ports": {
-			Description: "Open the required ports to communicate with one of the Hopsworks services.",
+			Description: "Open the required ports to communicate with one of the Hopsworks services. The ports are open to everyone, to limit the access run the cluster in your own network and apply the rules of the hopsworksai_network_requirements data source to its security group.",
 			Type:        schema.TypeList,
 			Optional:    true,
 			Computed:    true,
@@ -406,6 +582,27 @@ func clusterSchema() map[string]*schema.Schema {
 			Default:      api.Ubuntu,
 			ValidateFunc: validation.StringInSlice([]string{api.Ubuntu.String(), api.CentOS.String()}, false),
 		},
+		"endpoints": {
+			Description: "The endpoints to connect to the Hopsworks services running on the cluster.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"public": {
+						Description: "The endpoints reachable from outside the cluster network. An endpoint is empty if the cluster has no public ip attached or if the corresponding port is not open.",
+						Type:        schema.TypeList,
+						Computed:    true,
+						Elem:        clusterEndpointsSchema(),
+					},
+					"private": {
+						Description: "The endpoints reachable from within the cluster network.",
+						Type:        schema.TypeList,
+						Computed:    true,
+						Elem:        clusterEndpointsSchema(),
+					},
+				},
+			},
+		},
 		"upgrade_in_progress": {
 			Description: "Information about ongoing cluster upgrade if any.",
 			Type:        schema.TypeList,
@@ -837,7 +1034,7 @@ func awsAttributesSchema() *schema.Resource {
 										Optional:     true,
 										Computed:     true,
 										ForceNew:     true,
-										ValidateFunc: validation.StringMatch(regexp.MustCompile(`^arn:aws:kms:\w+(?:-\w+)+:\d{12}:(key|alias)/(.*)$`), "invalid key arn, make sure to either use the key arn or the alias arn."),
+										ValidateFunc: validation.StringMatch(regexp.MustCompile(`^arn:aws(?:-cn|-us-gov)?:kms:\w+(?:-\w+)+:\d{12}:(key|alias)/(.*)$`), "invalid key arn, make sure to either use the key arn or the alias arn."),
 									},
 									"bucket_key": {
 										Description: "Enable or disable the usage of bucket key. Enabling this option (in case of SSE-KMS) would reduce the cost of SSE-KMS.",
@@ -1177,15 +1374,28 @@ func gcpAttributesSchema() *schema.Resource {
 }
 
 func clusterResource() *schema.Resource {
+	clusterResourceSchema := clusterSchema()
+	clusterResourceSchema["final_backup"] = finalBackupSchema()
+	clusterResourceSchema["deletion_protection"] = deletionProtectionSchema()
+	clusterResourceSchema["auto_upgrade"] = autoUpgradeSchema()
+	clusterResourceSchema["auto_upgrade_target_version"] = autoUpgradeTargetVersionSchema()
+	clusterResourceSchema["upgrade"] = upgradeSchema()
+	clusterResourceSchema["version"].DiffSuppressFunc = resourceClusterAutoUpgradeVersionDiffSuppress
+
 	return &schema.Resource{
 		Description:   "Use this resource to create, read, update, and delete clusters on Hopsworks.ai.",
-		Schema:        clusterSchema(),
+		Schema:        clusterResourceSchema,
 		CreateContext: resourceClusterCreate,
-		ReadContext:   resourceClusterRead,
+		ReadContext:   resourceClusterWithAutoUpgradeRead,
 		UpdateContext: resourceClusterUpdate,
 		DeleteContext: resourceClusterDelete,
+		CustomizeDiff: customdiff.All(
+			resourceClusterDeletionProtectionCustomizeDiff(clusterResourceSchema),
+			resourceClusterDowngradeCustomizeDiff,
+			resourceClusterAutoUpgradeCustomizeDiff(time.Now),
+		),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceClusterImport,
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(45 * time.Minute),
@@ -1196,6 +1406,73 @@ func clusterResource() *schema.Resource {
 	}
 }
 
+func resourceClusterImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if strings.Contains(d.Id(), ":") {
+		return nil, fmt.Errorf("unexpected import id %s, clusters restored from a backup should be imported using hopsworksai_cluster_from_backup and <cluster_id>:<backup_id>", d.Id())
+	}
+	if err := importClusterState(ctx, d, meta, d.Id(), clusterResource().Schema); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// importClusterState resolves the cluster by either its id or its name and initializes the
+// attributes that are not returned by Hopsworks.ai to their defaults to avoid diffs after import.
+func importClusterState(ctx context.Context, d *schema.ResourceData, meta interface{}, clusterIdOrName string, resourceSchema map[string]*schema.Schema) error {
+	client := meta.(*api.HopsworksAIClient)
+
+	cluster, err := getClusterByIdOrName(ctx, client, clusterIdOrName)
+	if err != nil {
+		return err
+	}
+	if cluster == nil {
+		return fmt.Errorf("cluster not found for %s", clusterIdOrName)
+	}
+
+	d.SetId(cluster.Id)
+	return setImportDefaults(d, resourceSchema)
+}
+
+// setImportDefaults sets the optional attributes to their defaults, the attributes returned by
+// Hopsworks.ai are overwritten by the read that follows the import. Optional blocks, such as
+// final_backup and upgrade, are left unset since an unset block already means the default behavior.
+func setImportDefaults(d *schema.ResourceData, resourceSchema map[string]*schema.Schema) error {
+	for k, v := range resourceSchema {
+		if !v.Optional || v.Computed || v.Default == nil {
+			continue
+		}
+		if err := d.Set(k, v.Default); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getClusterByIdOrName(ctx context.Context, client *api.HopsworksAIClient, clusterIdOrName string) (*api.Cluster, error) {
+	cluster, err := api.GetCluster(ctx, client, clusterIdOrName)
+	if err != nil {
+		return nil, err
+	}
+	if cluster != nil {
+		return cluster, nil
+	}
+
+	clusters, err := api.GetClusters(ctx, client, "")
+	if err != nil {
+		return nil, err
+	}
+	var found *api.Cluster
+	for i := range clusters {
+		if clusters[i].Name == clusterIdOrName {
+			if found != nil {
+				return nil, fmt.Errorf("found more than one cluster with name %s, use the cluster id instead", clusterIdOrName)
+			}
+			found = &clusters[i]
+		}
+	}
+	return found, nil
+}
+
 func resourceClusterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*api.HopsworksAIClient)
 
@@ -1607,6 +1884,17 @@ func resourceClusterRead(ctx context.Context, d *schema.ResourceData, meta inter
 	return diags
 }
 
+func resourceClusterWithAutoUpgradeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	diags := resourceClusterRead(ctx, d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	if err := d.Set("auto_upgrade_target_version", d.Get("version")); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	return diags
+}
+
 func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*api.HopsworksAIClient)
 
@@ -1622,32 +1910,8 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, meta int
 		upgradeInProgressToVersion, upgradeInProgressToVersionOk := d.GetOk("upgrade_in_progress.0.to_version")
 
 		if !upgradeInProgressFromVersionOk && !upgradeInProgressToVersionOk {
-			clusterVersion := getHopsworksVersion(toVersion)
-
-			dockerRegistryAccount := ""
-			if clusterVersion.GreaterThan(getHopsworksVersion3_0()) {
-				if _, ok := d.GetOk("aws_attributes"); ok {
-					if v, okR := d.GetOk("aws_attributes.0.ecr_registry_account_id"); okR {
-						dockerRegistryAccount = v.(string)
-					} else {
-						dockerRegistryAccount = getECRRegistryAccountIdFromInstanceProfile(d.Get("aws_attributes.0.instance_profile_arn").(string))
-					}
-				}
-
-				if _, ok := d.GetOk("azure_attributes"); ok {
-					if v, okR := d.GetOk("azure_attributes.0.acr_registry_name"); okR {
-						dockerRegistryAccount = v.(string)
-					} else {
-						return diag.Errorf("To upgrade from %s to %s, you need to create an acr registry and configure it by setting attribute acr_registry_name", fromVersion, toVersion)
-					}
-				}
-			}
-
-			if err := api.UpgradeCluster(ctx, client, clusterId, toVersion, dockerRegistryAccount); err != nil {
-				return diag.FromErr(err)
-			}
-			if err := resourceClusterWaitForRunningAfterUpgrade(ctx, client, d.Timeout(schema.TimeoutUpdate), clusterId); err != nil {
-				return diag.FromErr(err)
+			if diags := resourceClusterUpgrade(ctx, client, d, fromVersion, toVersion); diags.HasError() {
+				return diags
 			}
 		} else if clusterState == api.Error.String() && upgradeInProgressToVersion.(string) == fromVersion && upgradeInProgressFromVersion.(string) == toVersion {
 			if err := api.RollbackUpgradeCluster(ctx, client, clusterId); err != nil {
@@ -1661,6 +1925,17 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, meta int
 		return resourceClusterRead(ctx, d, meta)
 	}
 
+	if d.HasChange("auto_upgrade_target_version") {
+		fromVersion := d.Get("version").(string)
+		toVersion := d.Get("auto_upgrade_target_version").(string)
+		if toVersion != "" && toVersion != fromVersion {
+			if diags := resourceClusterUpgrade(ctx, client, d, fromVersion, toVersion); diags.HasError() {
+				return diags
+			}
+			return resourceClusterRead(ctx, d, meta)
+		}
+	}
+
 	if d.HasChange("head.0.instance_type") {
 		_, n := d.GetChange("head.0.instance_type")
 		toInstanceType := n.(string)
@@ -1859,17 +2134,346 @@ func resourceClusterDelete(ctx context.Context, d *schema.ResourceData, meta int
 	id := d.Id()
 	var diags diag.Diagnostics
 
+	if d.Get("deletion_protection").(bool) {
+		return diag.Errorf("cannot delete cluster %s while deletion_protection is enabled, set deletion_protection to false and apply the change first", id)
+	}
+
+	// the final backup and the deletion share the delete timeout
+	deadline := time.Now().Add(d.Timeout(schema.TimeoutDelete))
+
+	if v, ok := d.GetOk("final_backup"); ok && len(v.([]interface{})) > 0 && d.Get("final_backup.0.enabled").(bool) {
+		backupId, err := resourceClusterCreateFinalBackup(ctx, client, d, deadline)
+		if err != nil {
+			return diag.Errorf("failed to create final backup before deleting the cluster, error: %s", err)
+		}
+		tflog.Info(ctx, fmt.Sprintf("final backup %s created for cluster %s", backupId, id))
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Final backup %s created for cluster %s", backupId, id),
+			Detail:   fmt.Sprintf("You can restore the cluster later using hopsworksai_cluster_from_backup with source_backup_id = \"%s\"", backupId),
+		})
+	}
+
 	if err := api.DeleteCluster(ctx, client, id); err != nil {
 		return diag.Errorf("failed to delete cluster, error: %s", err)
 	}
 
-	if err := resourceClusterWaitForDeleting(ctx, client, d.Timeout(schema.TimeoutDelete), id); err != nil {
+	if err := resourceClusterWaitForDeleting(ctx, client, time.Until(deadline), id); err != nil {
 		return diag.FromErr(err)
 	}
 
 	return diags
 }
 
+func resourceClusterDeletionProtectionCustomizeDiff(resourceSchema map[string]*schema.Schema) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if d.Id() == "" {
+			return nil
+		}
+		// use the value from the state so that disabling the protection has to be applied on its own
+		if o, _ := d.GetChange("deletion_protection"); !o.(bool) {
+			return nil
+		}
+		keys := d.GetChangedKeysPrefix("")
+		sort.Strings(keys)
+		for _, k := range keys {
+			if helpers.IsForceNewKey(resourceSchema, k) && d.HasChange(k) {
+				return fmt.Errorf("cannot apply the change on %s as it requires replacing the cluster while deletion_protection is enabled, set deletion_protection to false and apply the change first", k)
+			}
+		}
+		return nil
+	}
+}
+
+func resourceClusterUpgrade(ctx context.Context, client *api.HopsworksAIClient, d *schema.ResourceData, fromVersion string, toVersion string) diag.Diagnostics {
+	clusterId := d.Id()
+
+	var cloud api.CloudProvider
+	if _, ok := d.GetOk("aws_attributes"); ok {
+		cloud = api.AWS
+	} else if _, ok := d.GetOk("azure_attributes"); ok {
+		cloud = api.AZURE
+	}
+	dockerRegistryAccount, err := getDockerRegistryAccountForUpgrade(cloud, fromVersion, toVersion,
+		d.Get("aws_attributes.0.ecr_registry_account_id").(string),
+		d.Get("aws_attributes.0.instance_profile_arn").(string),
+		d.Get("azure_attributes.0.acr_registry_name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := api.UpgradeCluster(ctx, client, clusterId, toVersion, dockerRegistryAccount); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := resourceClusterWaitForRunningAfterUpgrade(ctx, client, d.Timeout(schema.TimeoutUpdate), clusterId); err != nil {
+		if onFailure, ok := d.GetOk("upgrade.0.on_failure"); ok && onFailure.(string) == upgradeOnFailureRollback {
+			// only roll back if the upgrade has failed, timeouts and polling errors leave the upgrade running
+			if cluster, getErr := api.GetCluster(ctx, client, clusterId); getErr == nil && isUpgradeFailed(cluster) {
+				return resourceClusterRollbackFailedUpgrade(ctx, client, d, fromVersion, toVersion, err)
+			}
+		}
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// isUpgradeFailed checks if the cluster ended up in an error state while upgrading
+func isUpgradeFailed(cluster *api.Cluster) bool {
+	if cluster == nil || cluster.UpgradeInProgress == nil {
+		return false
+	}
+	switch cluster.State {
+	case api.Error, api.WorkerError, api.CommandFailed, api.SecondaryError:
+		return true
+	}
+	return false
+}
+
+// resourceClusterRollbackFailedUpgrade rolls back the failed upgrade and keeps the version before the upgrade in the state
+func resourceClusterRollbackFailedUpgrade(ctx context.Context, client *api.HopsworksAIClient, d *schema.ResourceData, fromVersion string, toVersion string, upgradeErr error) diag.Diagnostics {
+	clusterId := d.Id()
+
+	tflog.Info(ctx, fmt.Sprintf("rolling back the failed upgrade of cluster %s from %s to %s", clusterId, fromVersion, toVersion))
+	if err := api.RollbackUpgradeCluster(ctx, client, clusterId); err != nil {
+		return diag.Errorf("failed to upgrade cluster from %s to %s: %s, and failed to roll back the upgrade: %s", fromVersion, toVersion, upgradeErr, err)
+	}
+	if err := resourceClusterWaitForStopping(ctx, client, d.Timeout(schema.TimeoutUpdate), clusterId); err != nil {
+		return diag.Errorf("failed to upgrade cluster from %s to %s: %s, and failed while waiting for the rollback: %s", fromVersion, toVersion, upgradeErr, err)
+	}
+
+	if err := d.Set("version", fromVersion); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("auto_upgrade_target_version", fromVersion); err != nil {
+		return diag.FromErr(err)
+	}
+	return diag.Errorf("failed to upgrade cluster from %s to %s, the upgrade has been rolled back: %s", fromVersion, toVersion, upgradeErr)
+}
+
+func isDockerRegistryRequiredForUpgrade(toVersion string) bool {
+	clusterVersion := getHopsworksVersion(toVersion)
+	return clusterVersion != nil && clusterVersion.GreaterThan(getHopsworksVersion3_0())
+}
+
+// getDockerRegistryAccountForUpgrade returns the docker registry account used to upgrade the cluster, it is empty if the target version does not need one
+func getDockerRegistryAccountForUpgrade(cloud api.CloudProvider, fromVersion string, toVersion string, ecrRegistryAccountId string, instanceProfileArn string, acrRegistryName string) (string, error) {
+	if !isDockerRegistryRequiredForUpgrade(toVersion) {
+		return "", nil
+	}
+	switch cloud {
+	case api.AWS:
+		if ecrRegistryAccountId != "" {
+			return ecrRegistryAccountId, nil
+		}
+		return getECRRegistryAccountIdFromInstanceProfile(instanceProfileArn), nil
+	case api.AZURE:
+		if acrRegistryName != "" {
+			return acrRegistryName, nil
+		}
+		return "", fmt.Errorf("To upgrade from %s to %s, you need to create an acr registry and configure it by setting attribute acr_registry_name", fromVersion, toVersion)
+	}
+	return "", nil
+}
+
+// resourceClusterAutoUpgradeVersionDiffSuppress suppresses the rollback to the configured version after the cluster has been upgraded by auto_upgrade
+func resourceClusterAutoUpgradeVersionDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	policy, ok := d.GetOk("auto_upgrade.0.policy")
+	if !ok || old == "" || new == "" {
+		return false
+	}
+	if _, ok := d.GetOk("upgrade_in_progress"); ok {
+		return false
+	}
+	oldVersion := getHopsworksVersion(old)
+	newVersion := getHopsworksVersion(new)
+	if oldVersion == nil || newVersion == nil {
+		return false
+	}
+	return isAutoUpgradedVersion(newVersion, oldVersion, policy.(string))
+}
+
+// isAutoUpgradedVersion checks if the cluster could have been upgraded by auto_upgrade from the configured version to the current version
+func isAutoUpgradedVersion(configuredVersion *version.Version, currentVersion *version.Version, policy string) bool {
+	return currentVersion.GreaterThan(configuredVersion) && isAutoUpgradeAllowed(configuredVersion, currentVersion, policy)
+}
+
+// resourceClusterDowngradeCustomizeDiff rejects setting version to an older version than the one auto_upgrade upgraded the cluster to, unless it rolls back a failed upgrade
+func resourceClusterDowngradeCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("version") {
+		return nil
+	}
+	policy, ok := d.GetOk("auto_upgrade.0.policy")
+	if !ok {
+		return nil
+	}
+	if _, ok := d.GetOk("upgrade_in_progress"); ok {
+		return nil
+	}
+	o, n := d.GetChange("version")
+	oldVersion := getHopsworksVersion(o.(string))
+	newVersion := getHopsworksVersion(n.(string))
+	if oldVersion == nil || newVersion == nil || !oldVersion.GreaterThan(newVersion) {
+		return nil
+	}
+	// the change is suppressed by resourceClusterAutoUpgradeVersionDiffSuppress
+	if isAutoUpgradedVersion(newVersion, oldVersion, policy.(string)) {
+		return nil
+	}
+	return fmt.Errorf("cannot change version from %s to %s as downgrading a cluster is not supported, set version to %s or a newer version", o.(string), n.(string), o.(string))
+}
+
+func resourceClusterAutoUpgradeCustomizeDiff(now func() time.Time) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if d.Id() == "" {
+			return nil
+		}
+		policy, ok := d.GetOk("auto_upgrade.0.policy")
+		if !ok {
+			return nil
+		}
+		// explicit version changes and ongoing upgrades take precedence over auto upgrades
+		if d.HasChange("version") {
+			return nil
+		}
+		if _, ok := d.GetOk("upgrade_in_progress"); ok {
+			return nil
+		}
+		if _, ok := d.GetOk("auto_upgrade.0.maintenance_window"); ok {
+			days := toStringList(d.Get("auto_upgrade.0.maintenance_window.0.days").(*schema.Set).List())
+			startTime := d.Get("auto_upgrade.0.maintenance_window.0.start_time").(string)
+			duration := d.Get("auto_upgrade.0.maintenance_window.0.duration_hours").(int)
+			if !isInMaintenanceWindow(now().UTC(), days, startTime, duration) {
+				return nil
+			}
+		}
+
+		var cloud api.CloudProvider
+		if _, ok := d.GetOk("aws_attributes"); ok {
+			cloud = api.AWS
+		} else if _, ok := d.GetOk("azure_attributes"); ok {
+			cloud = api.AZURE
+		} else if _, ok := d.GetOk("gcp_attributes"); ok {
+			cloud = api.GCP
+		} else {
+			return nil
+		}
+
+		client := meta.(*api.HopsworksAIClient)
+		supportedVersions, err := api.GetSupportedVersions(ctx, client, cloud)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve the supported versions for auto_upgrade: %s", err)
+		}
+
+		currentVersion := d.Get("version").(string)
+		targetVersion := getAutoUpgradeVersion(currentVersion, policy.(string), supportedVersions)
+		if targetVersion == "" || targetVersion == d.Get("auto_upgrade_target_version").(string) {
+			return nil
+		}
+		tflog.Info(ctx, fmt.Sprintf("auto_upgrade proposes to upgrade cluster %s from %s to %s", d.Id(), currentVersion, targetVersion))
+		return d.SetNew("auto_upgrade_target_version", targetVersion)
+	}
+}
+
+// getAutoUpgradeVersion returns the latest non experimental version that is upgradeable from the current version and allowed by the policy
+func getAutoUpgradeVersion(currentVersion string, policy string, supportedVersions []api.SupportedVersion) string {
+	current := getHopsworksVersion(currentVersion)
+	if current == nil {
+		return ""
+	}
+	var target *version.Version
+	targetVersion := ""
+	for _, v := range supportedVersions {
+		if v.Experimental {
+			continue
+		}
+		upgradeableFrom := getHopsworksVersion(v.UpgradableFromVersion)
+		if upgradeableFrom == nil || !upgradeableFrom.Equal(current) {
+			continue
+		}
+		candidate := getHopsworksVersion(v.Version)
+		if candidate == nil || !candidate.GreaterThan(current) || !isAutoUpgradeAllowed(current, candidate, policy) {
+			continue
+		}
+		if target == nil || candidate.GreaterThan(target) {
+			target = candidate
+			targetVersion = v.Version
+		}
+	}
+	return targetVersion
+}
+
+func isAutoUpgradeAllowed(from *version.Version, to *version.Version, policy string) bool {
+	fromSegments := from.Segments()
+	toSegments := to.Segments()
+	switch policy {
+	case autoUpgradePolicyPatch:
+		return fromSegments[0] == toSegments[0] && fromSegments[1] == toSegments[1]
+	case autoUpgradePolicyMinor:
+		return fromSegments[0] == toSegments[0]
+	}
+	return false
+}
+
+// isInMaintenanceWindow checks if t falls in a maintenance window, a window could start on the previous day and span midnight
+func isInMaintenanceWindow(t time.Time, days []string, startTime string, durationHours int) bool {
+	start, err := time.Parse("15:04", startTime)
+	if err != nil {
+		return false
+	}
+	for _, offset := range []int{0, -1} {
+		day := t.AddDate(0, 0, offset)
+		windowStart := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, time.UTC)
+		if len(days) > 0 && !contains(days, strings.ToLower(windowStart.Weekday().String())) {
+			continue
+		}
+		windowEnd := windowStart.Add(time.Duration(durationHours) * time.Hour)
+		if !t.Before(windowStart) && t.Before(windowEnd) {
+			return true
+		}
+	}
+	return false
+}
+
+func resourceClusterCreateFinalBackup(ctx context.Context, client *api.HopsworksAIClient, d *schema.ResourceData, deadline time.Time) (string, error) {
+	clusterId := d.Id()
+
+	cluster, err := api.GetCluster(ctx, client, clusterId)
+	if err != nil {
+		return "", err
+	}
+	if cluster == nil {
+		return "", fmt.Errorf("cluster not found for cluster id %s", clusterId)
+	}
+
+	// backups can only be taken for stopped clusters
+	if cluster.State != api.Stopped && cluster.State != api.ExternallyStopped {
+		if err := api.StopCluster(ctx, client, clusterId); err != nil {
+			return "", fmt.Errorf("failed to stop cluster: %s", err)
+		}
+		if err := resourceClusterWaitForStopping(ctx, client, time.Until(deadline), clusterId); err != nil {
+			return "", err
+		}
+	}
+
+	backupName := fmt.Sprintf("%s-%s", d.Get("final_backup.0.name_prefix").(string), time.Now().UTC().Format("20060102150405"))
+	backupId, err := api.NewBackup(ctx, client, clusterId, backupName)
+	if err != nil {
+		return "", err
+	}
+
+	if d.Get("final_backup.0.wait").(bool) {
+		if err := resourceBackupWaitForCompletion(ctx, client, time.Until(deadline), backupId, clusterId); err != nil {
+			return "", err
+		}
+	} else {
+		// the cluster can only be deleted once the backup has started processing
+		if err := resourceBackupWaitForProcessing(ctx, client, time.Until(deadline), backupId, clusterId); err != nil {
+			return "", err
+		}
+	}
+	return backupId, nil
+}
+
 func resourceClusterWaitForRunning(ctx context.Context, client *api.HopsworksAIClient, timeout time.Duration, clusterId string) error {
 	return resourceClusterWaitForRunningBase(ctx, client, timeout, clusterId, false)
 }
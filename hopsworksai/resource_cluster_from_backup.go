@@ -2,6 +2,7 @@ package hopsworksai
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
@@ -71,6 +72,8 @@ func clusterFromBackupResource() *schema.Resource {
 	baseSchema["update_state"] = clusterResourceSchema["update_state"]
 	baseSchema["open_ports"] = clusterResourceSchema["open_ports"]
 	baseSchema["workers"] = clusterResourceSchema["workers"]
+	baseSchema["final_backup"] = finalBackupSchema()
+	baseSchema["deletion_protection"] = deletionProtectionSchema()
 
 	return &schema.Resource{
 		Description:   "Use this resource to create a cluster from an existing backup.",
@@ -79,6 +82,10 @@ func clusterFromBackupResource() *schema.Resource {
 		ReadContext:   resourceClusterRead,
 		UpdateContext: resourceClusterUpdate,
 		DeleteContext: resourceClusterDelete,
+		CustomizeDiff: resourceClusterDeletionProtectionCustomizeDiff(baseSchema),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceClusterFromBackupImport,
+		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(4 * time.Hour),
 			Read:   schema.DefaultTimeout(5 * time.Minute),
@@ -88,6 +95,35 @@ func clusterFromBackupResource() *schema.Resource {
 	}
 }
 
+func resourceClusterFromBackupImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*api.HopsworksAIClient)
+
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of import id (%s), expected <cluster_id>:<backup_id> or <cluster_name>:<backup_id>", d.Id())
+	}
+
+	backupId := parts[1]
+	backup, err := api.GetBackup(ctx, client, backupId)
+	if err != nil {
+		return nil, err
+	}
+	if backup == nil {
+		return nil, fmt.Errorf("backup not found for backup_id %s", backupId)
+	}
+
+	if err := importClusterState(ctx, d, meta, parts[0], clusterFromBackupResource().Schema); err != nil {
+		return nil, err
+	}
+	if backup.ClusterId != d.Id() {
+		return nil, fmt.Errorf("backup %s belongs to cluster %s and not to the imported cluster %s", backup.Id, backup.ClusterId, d.Id())
+	}
+	if err := d.Set("source_backup_id", backup.Id); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceClusterFromBackupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*api.HopsworksAIClient)
 
@@ -824,3 +824,132 @@ func TestClusterFromBackupCreate_GCP_update(t *testing.T) {
 		},
 	})
 }
+
+func TestClusterFromBackupImport(t *testing.T) {
+	t.Parallel()
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			{
+				Method: http.MethodGet,
+				Path:   "/api/backups/backup-id-1",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200,
+					"payload":{
+						"backup": {
+							"backupId": "backup-id-1",
+							"backupName": "backup-1",
+							"clusterId": "cluster-id-1",
+							"cloudProvider": "AWS",
+							"state": "succeed"
+						}
+					}
+				}`,
+			},
+			{
+				Method: http.MethodGet,
+				Path:   "/api/clusters/cluster-id-1",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200,
+					"payload":{
+						"cluster": {
+							"id": "cluster-id-1",
+							"name": "cluster-name-1"
+						}
+					}
+				}`,
+			},
+		},
+		Resource:             clusterFromBackupResource(),
+		OperationContextFunc: testImportStateContext(clusterFromBackupResource().Importer.StateContext),
+		Id:                   "cluster-id-1:backup-id-1",
+		ExpectId:             "cluster-id-1",
+		ExpectState: map[string]interface{}{
+			"source_backup_id": "backup-id-1",
+			"update_state":     "none",
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClusterFromBackupImport_backupOfAnotherCluster(t *testing.T) {
+	t.Parallel()
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			{
+				Method: http.MethodGet,
+				Path:   "/api/backups/backup-id-1",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200,
+					"payload":{
+						"backup": {
+							"backupId": "backup-id-1",
+							"backupName": "backup-1",
+							"clusterId": "cluster-id-0",
+							"cloudProvider": "AWS",
+							"state": "succeed"
+						}
+					}
+				}`,
+			},
+			{
+				Method: http.MethodGet,
+				Path:   "/api/clusters/cluster-id-1",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200,
+					"payload":{
+						"cluster": {
+							"id": "cluster-id-1",
+							"name": "cluster-name-1"
+						}
+					}
+				}`,
+			},
+		},
+		Resource:             clusterFromBackupResource(),
+		OperationContextFunc: testImportStateContext(clusterFromBackupResource().Importer.StateContext),
+		Id:                   "cluster-id-1:backup-id-1",
+		ExpectError:          "backup backup-id-1 belongs to cluster cluster-id-0 and not to the imported cluster cluster-id-1",
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClusterFromBackupImport_invalidId(t *testing.T) {
+	t.Parallel()
+	r := test.ResourceFixture{
+		Resource:             clusterFromBackupResource(),
+		OperationContextFunc: testImportStateContext(clusterFromBackupResource().Importer.StateContext),
+		Id:                   "cluster-id-1",
+		ExpectError:          "unexpected format of import id (cluster-id-1), expected <cluster_id>:<backup_id> or <cluster_name>:<backup_id>",
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClusterFromBackupImport_backupNotFound(t *testing.T) {
+	t.Parallel()
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			{
+				Method: http.MethodGet,
+				Path:   "/api/backups/backup-id-1",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 404
+				}`,
+			},
+		},
+		Resource:             clusterFromBackupResource(),
+		OperationContextFunc: testImportStateContext(clusterFromBackupResource().Importer.StateContext),
+		Id:                   "cluster-id-1:backup-id-1",
+		ExpectError:          "backup not found for backup_id backup-id-1",
+	}
+	r.Apply(t, context.TODO())
+}
@@ -14,11 +14,15 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	terraformSDK "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/api"
+	apitest "github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/api/test"
 	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/helpers"
 	"github.com/logicalclocks/terraform-provider-hopsworksai/hopsworksai/internal/test"
 )
@@ -3102,6 +3106,263 @@ func TestClusterUpdate_upgrade_rollback_error(t *testing.T) {
 	r.Apply(t, context.TODO())
 }
 
+func testClusterUpdateUpgradeClusterResponse(state api.ClusterState, version string, extra string) string {
+	return fmt.Sprintf(`{
+		"apiVersion": "v1",
+		"status": "ok",
+		"code": 200,
+		"payload":{
+			"cluster": {
+				"id": "cluster-id-1",
+				"name": "cluster-name-1",
+				"state" : "%s",
+				"provider": "AZURE",
+				"version": "%s",
+				%s
+				"ports":{
+					"featureStore": false,
+					"onlineFeatureStore": false,
+					"kafka": false,
+					"ssh": false
+				}
+			}
+		}
+	}`, state.String(), version, extra)
+}
+
+func testClusterUpdateUpgradeFailureOperations(rollbackOps ...test.Operation) []test.Operation {
+	failedUpgradeOperation := test.Operation{
+		Method: http.MethodGet,
+		Path:   "/api/clusters/cluster-id-1",
+		Response: testClusterUpdateUpgradeClusterResponse(api.Error, "v2", `"errorMessage": "failed to run the upgrade",
+				"upgradeInProgress": {
+					"from": "v1",
+					"to": "v2"
+				},`),
+		RunOnlyOnce: len(rollbackOps) > 0,
+	}
+	ops := []test.Operation{
+		{
+			Method:      http.MethodGet,
+			Path:        "/api/clusters/cluster-id-1",
+			Response:    testClusterUpdateUpgradeClusterResponse(api.Running, "v1", ""),
+			RunOnlyOnce: true,
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/clusters/cluster-id-1/upgrade",
+			ExpectRequestBody: `{
+				"version": "v2"
+			}`,
+			Response: `{
+				"apiVersion": "v1",
+				"status": "ok",
+				"code": 200
+			}`,
+		},
+		failedUpgradeOperation,
+	}
+	if len(rollbackOps) > 0 {
+		// the cluster is read again to check that the upgrade has failed before rolling it back
+		ops = append(ops, failedUpgradeOperation)
+		ops = append(ops, rollbackOps...)
+		ops = append(ops, test.Operation{
+			Method:   http.MethodGet,
+			Path:     "/api/clusters/cluster-id-1",
+			Response: testClusterUpdateUpgradeClusterResponse(api.Stopped, "v1", ""),
+		})
+	}
+	return ops
+}
+
+func testClusterUpdateUpgradeFailureState(onFailure string) map[string]interface{} {
+	state := map[string]interface{}{
+		"version": "v2",
+		"open_ports": []interface{}{
+			map[string]interface{}{
+				"ssh":                  false,
+				"kafka":                false,
+				"feature_store":        false,
+				"online_feature_store": false,
+			},
+		},
+	}
+	if onFailure != "" {
+		state["upgrade"] = []interface{}{
+			map[string]interface{}{
+				"on_failure": onFailure,
+			},
+		}
+	}
+	return state
+}
+
+func TestClusterUpdate_upgrade_onFailure_leave(t *testing.T) {
+	t.Parallel()
+	r := test.ResourceFixture{
+		HttpOps:              testClusterUpdateUpgradeFailureOperations(),
+		Resource:             clusterResource(),
+		OperationContextFunc: clusterResource().UpdateContext,
+		Id:                   "cluster-id-1",
+		Update:               true,
+		State:                testClusterUpdateUpgradeFailureState(""),
+		ExpectError:          "failed while waiting for the cluster to reach running state: failed to run the upgrade",
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClusterUpdate_upgrade_onFailure_rollback(t *testing.T) {
+	t.Parallel()
+	r := test.ResourceFixture{
+		HttpOps: testClusterUpdateUpgradeFailureOperations(test.Operation{
+			Method: http.MethodPut,
+			Path:   "/api/clusters/cluster-id-1/upgrade/rollback",
+			Response: `{
+				"apiVersion": "v1",
+				"status": "ok",
+				"code": 200
+			}`,
+			RunOnlyOnce: true,
+		}),
+		Resource:             clusterResource(),
+		OperationContextFunc: clusterResource().UpdateContext,
+		Id:                   "cluster-id-1",
+		Update:               true,
+		State:                testClusterUpdateUpgradeFailureState(upgradeOnFailureRollback),
+		ExpectError:          "failed to upgrade cluster from v1 to v2, the upgrade has been rolled back: failed while waiting for the cluster to reach running state: failed to run the upgrade",
+		ExpectState: map[string]interface{}{
+			"version":                     "v1",
+			"auto_upgrade_target_version": "v1",
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClusterUpdate_upgrade_onFailure_rollback_error(t *testing.T) {
+	t.Parallel()
+	r := test.ResourceFixture{
+		HttpOps: testClusterUpdateUpgradeFailureOperations(test.Operation{
+			Method: http.MethodPut,
+			Path:   "/api/clusters/cluster-id-1/upgrade/rollback",
+			Response: `{
+				"apiVersion": "v1",
+				"status": "error",
+				"code": 400,
+				"message": "failed to rollback upgrade"
+			}`,
+			RunOnlyOnce: true,
+		}),
+		Resource:             clusterResource(),
+		OperationContextFunc: clusterResource().UpdateContext,
+		Id:                   "cluster-id-1",
+		Update:               true,
+		State:                testClusterUpdateUpgradeFailureState(upgradeOnFailureRollback),
+		ExpectError:          "failed to upgrade cluster from v1 to v2: failed while waiting for the cluster to reach running state: failed to run the upgrade, and failed to roll back the upgrade: failed to rollback upgrade",
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClusterUpdate_upgrade_onFailure_rollback_timeout(t *testing.T) {
+	t.Parallel()
+	r := clusterResource()
+	r.Timeouts.Update = schema.DefaultTimeout(time.Second)
+	baseConfig := map[string]interface{}{
+		"name":    "cluster-name-1",
+		"version": "v1",
+		"upgrade": []interface{}{
+			map[string]interface{}{
+				"on_failure": upgradeOnFailureRollback,
+			},
+		},
+	}
+	data := schema.TestResourceDataRaw(t, r.Schema, baseConfig)
+	data.SetId("cluster-id-1")
+	state := data.State()
+
+	baseConfig["version"] = "v2"
+	// the cluster is still upgrading when the update times out, so the upgrade should not be rolled back
+	client := &api.HopsworksAIClient{
+		Client: &apitest.HttpClientFixture{
+			ResponseBody: testClusterUpdateUpgradeClusterResponse(api.Updating, "v2", `"upgradeInProgress": {
+				"from": "v1",
+				"to": "v2"
+			},`),
+			ResponseCode: http.StatusOK,
+			T:            t,
+		},
+	}
+	diff, err := r.Diff(context.TODO(), state, terraformSDK.NewResourceConfigRaw(baseConfig), client)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	newState, diags := r.Apply(context.TODO(), state, diff, client)
+	// the wait and the update context share the same deadline, so either of them could time out first
+	expected := []string{
+		"timeout while waiting for state to become 'running, error, worker-error, command-failed, secondary-error, externally-shutting-down, externally-terminated' (timeout: 1s)",
+		"context deadline exceeded",
+	}
+	if !diags.HasError() || !contains(expected, diags[0].Summary) {
+		t.Fatalf("expected a timeout error but got %#v", diags)
+	}
+	if newState.Attributes["version"] != "v2" {
+		t.Fatalf("expected version to remain v2 but got %s", newState.Attributes["version"])
+	}
+}
+
+func TestClusterUpdate_upgrade_onFailure_rollback_pollingError(t *testing.T) {
+	t.Parallel()
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			{
+				Method:      http.MethodGet,
+				Path:        "/api/clusters/cluster-id-1",
+				Response:    testClusterUpdateUpgradeClusterResponse(api.Running, "v1", ""),
+				RunOnlyOnce: true,
+			},
+			{
+				Method: http.MethodPost,
+				Path:   "/api/clusters/cluster-id-1/upgrade",
+				ExpectRequestBody: `{
+					"version": "v2"
+				}`,
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200
+				}`,
+				RunOnlyOnce: true,
+			},
+			{
+				Method: http.MethodGet,
+				Path:   "/api/clusters/cluster-id-1",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "error",
+					"code": 500,
+					"message": "internal server error"
+				}`,
+				RunOnlyOnce: true,
+			},
+			// the cluster is still upgrading, the rollback is not registered and fails the update if called
+			{
+				Method: http.MethodGet,
+				Path:   "/api/clusters/cluster-id-1",
+				Response: testClusterUpdateUpgradeClusterResponse(api.Updating, "v2", `"upgradeInProgress": {
+					"from": "v1",
+					"to": "v2"
+				},`),
+			},
+		},
+		Resource:             clusterResource(),
+		OperationContextFunc: clusterResource().UpdateContext,
+		Id:                   "cluster-id-1",
+		Update:               true,
+		State:                testClusterUpdateUpgradeFailureState(upgradeOnFailureRollback),
+		ExpectError:          "internal server error",
+	}
+	r.Apply(t, context.TODO())
+}
+
 func TestClusterCreate_AZURE_container(t *testing.T) {
 	r := test.ResourceFixture{
 		HttpOps: []test.Operation{
@@ -6675,3 +6936,1121 @@ func TestClusterRead_GCP(t *testing.T) {
 	}
 	r.Apply(t, context.TODO())
 }
+
+func testImportStateContext(importer schema.StateContextFunc) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if _, err := importer(ctx, d, meta); err != nil {
+			return diag.FromErr(err)
+		}
+		return nil
+	}
+}
+
+func TestClusterImport_byId(t *testing.T) {
+	t.Parallel()
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			{
+				Method: http.MethodGet,
+				Path:   "/api/clusters/cluster-id-1",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200,
+					"payload":{
+						"cluster": {
+							"id": "cluster-id-1",
+							"name": "cluster-name-1"
+						}
+					}
+				}`,
+			},
+		},
+		Resource:             clusterResource(),
+		OperationContextFunc: testImportStateContext(clusterResource().Importer.StateContext),
+		Id:                   "cluster-id-1",
+		ExpectId:             "cluster-id-1",
+		ExpectState: map[string]interface{}{
+			"update_state":        "none",
+			"deletion_protection": false,
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClusterImport_defaults(t *testing.T) {
+	t.Parallel()
+	r := clusterResource()
+	d := r.TestResourceData()
+	d.SetId("cluster-id-1")
+	client := &api.HopsworksAIClient{
+		Client: &apitest.HttpClientFixture{
+			ExpectMethod: http.MethodGet,
+			ExpectPath:   "/api/clusters/cluster-id-1",
+			ResponseBody: `{
+				"apiVersion": "v1",
+				"status": "ok",
+				"code": 200,
+				"payload":{
+					"cluster": {
+						"id": "cluster-id-1",
+						"name": "cluster-name-1"
+					}
+				}
+			}`,
+			ResponseCode: http.StatusOK,
+			T:            t,
+		},
+	}
+	if _, err := r.Importer.StateContext(context.TODO(), d, client); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	attributes := d.State().Attributes
+	expected := map[string]string{
+		"update_state":        "none",
+		"deletion_protection": "false",
+	}
+	for k, v := range expected {
+		if attributes[k] != v {
+			t.Fatalf("expected %s to be %s but got %s", k, v, attributes[k])
+		}
+	}
+	for k, v := range r.Schema {
+		if v.Optional && !v.Computed && v.Default != nil {
+			if _, ok := attributes[k]; !ok {
+				t.Fatalf("expected %s to be set to its default after import", k)
+			}
+		}
+	}
+	for _, k := range []string{"final_backup.#", "upgrade.#", "auto_upgrade.#"} {
+		if v, ok := attributes[k]; ok && v != "0" {
+			t.Fatalf("expected %s to be unset after import but got %s", k, v)
+		}
+	}
+}
+
+func TestClusterImport_byName(t *testing.T) {
+	t.Parallel()
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			{
+				Method: http.MethodGet,
+				Path:   "/api/clusters/cluster-name-2",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 404
+				}`,
+			},
+			{
+				Method: http.MethodGet,
+				Path:   "/api/clusters",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200,
+					"payload":{
+						"clusters": [
+							{
+								"id": "cluster-id-1",
+								"name": "cluster-name-1"
+							},
+							{
+								"id": "cluster-id-2",
+								"name": "cluster-name-2"
+							}
+						]
+					}
+				}`,
+			},
+		},
+		Resource:             clusterResource(),
+		OperationContextFunc: testImportStateContext(clusterResource().Importer.StateContext),
+		Id:                   "cluster-name-2",
+		ExpectId:             "cluster-id-2",
+		ExpectState: map[string]interface{}{
+			"update_state": "none",
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClusterImport_duplicateName(t *testing.T) {
+	t.Parallel()
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			{
+				Method: http.MethodGet,
+				Path:   "/api/clusters/cluster-name-1",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 404
+				}`,
+			},
+			{
+				Method: http.MethodGet,
+				Path:   "/api/clusters",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200,
+					"payload":{
+						"clusters": [
+							{
+								"id": "cluster-id-1",
+								"name": "cluster-name-1"
+							},
+							{
+								"id": "cluster-id-2",
+								"name": "cluster-name-1"
+							}
+						]
+					}
+				}`,
+			},
+		},
+		Resource:             clusterResource(),
+		OperationContextFunc: testImportStateContext(clusterResource().Importer.StateContext),
+		Id:                   "cluster-name-1",
+		ExpectError:          "found more than one cluster with name cluster-name-1, use the cluster id instead",
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClusterImport_notFound(t *testing.T) {
+	t.Parallel()
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			{
+				Method: http.MethodGet,
+				Path:   "/api/clusters/cluster-id-1",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 404
+				}`,
+			},
+			{
+				Method: http.MethodGet,
+				Path:   "/api/clusters",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200,
+					"payload":{
+						"clusters": []
+					}
+				}`,
+			},
+		},
+		Resource:             clusterResource(),
+		OperationContextFunc: testImportStateContext(clusterResource().Importer.StateContext),
+		Id:                   "cluster-id-1",
+		ExpectError:          "cluster not found for cluster-id-1",
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClusterImport_fromBackupId(t *testing.T) {
+	t.Parallel()
+	r := test.ResourceFixture{
+		Resource:             clusterResource(),
+		OperationContextFunc: testImportStateContext(clusterResource().Importer.StateContext),
+		Id:                   "cluster-id-1:backup-id-1",
+		ExpectError:          "unexpected import id cluster-id-1:backup-id-1, clusters restored from a backup should be imported using hopsworksai_cluster_from_backup and <cluster_id>:<backup_id>",
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClusterDelete_finalBackup(t *testing.T) {
+	t.Parallel()
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			{
+				Method: http.MethodGet,
+				Path:   "/api/clusters/cluster-id-1",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200,
+					"payload":{
+						"cluster": {
+							"id": "cluster-id-1",
+							"state": "running"
+						}
+					}
+				}`,
+				RunOnlyOnce: true,
+			},
+			{
+				Method: http.MethodPut,
+				Path:   "/api/clusters/cluster-id-1/stop",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200
+				}`,
+				RunOnlyOnce: true,
+			},
+			{
+				Method: http.MethodGet,
+				Path:   "/api/clusters/cluster-id-1",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200,
+					"payload":{
+						"cluster": {
+							"id": "cluster-id-1",
+							"state": "stopped"
+						}
+					}
+				}`,
+				RunOnlyOnce: true,
+			},
+			{
+				Method: http.MethodPost,
+				Path:   "/api/backups",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200,
+					"payload": {
+						"backupId": "backup-id-1"
+					}
+				}`,
+				CheckRequestBody: func(reqBody io.Reader) error {
+					var req api.NewBackupRequest
+					if err := json.NewDecoder(reqBody).Decode(&req); err != nil {
+						return err
+					}
+					if req.Backup.ClusterId != "cluster-id-1" {
+						return fmt.Errorf("expected cluster id cluster-id-1 but got %s", req.Backup.ClusterId)
+					}
+					if !strings.HasPrefix(req.Backup.BackupName, "my-prefix-") {
+						return fmt.Errorf("expected backup name to start with my-prefix- but got %s", req.Backup.BackupName)
+					}
+					return nil
+				},
+				RunOnlyOnce: true,
+			},
+			{
+				Method: http.MethodGet,
+				Path:   "/api/clusters/cluster-id-1",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200,
+					"payload":{
+						"cluster": {
+							"id": "cluster-id-1",
+							"state": "stopped",
+							"backupPipelineInProgress": false
+						}
+					}
+				}`,
+				RunOnlyOnce: true,
+			},
+			{
+				Method: http.MethodGet,
+				Path:   "/api/backups/backup-id-1",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200,
+					"payload":{
+						"backup": {
+							"backupId": "backup-id-1",
+							"clusterId": "cluster-id-1",
+							"state": "succeed"
+						}
+					}
+				}`,
+				RunOnlyOnce: true,
+			},
+			{
+				Method: http.MethodDelete,
+				Path:   "/api/clusters/cluster-id-1",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200
+				}`,
+				RunOnlyOnce: true,
+			},
+			{
+				Method: http.MethodGet,
+				Path:   "/api/clusters/cluster-id-1",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 404
+				}`,
+			},
+		},
+		Resource:             clusterResource(),
+		OperationContextFunc: clusterResource().DeleteContext,
+		Id:                   "cluster-id-1",
+		State: map[string]interface{}{
+			"final_backup": []interface{}{
+				map[string]interface{}{
+					"name_prefix": "my-prefix",
+				},
+			},
+		},
+		ExpectWarning: "Final backup backup-id-1 created for cluster cluster-id-1",
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClusterDelete_finalBackup_noWait(t *testing.T) {
+	t.Parallel()
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			{
+				Method: http.MethodGet,
+				Path:   "/api/clusters/cluster-id-1",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200,
+					"payload":{
+						"cluster": {
+							"id": "cluster-id-1",
+							"state": "stopped"
+						}
+					}
+				}`,
+				RunOnlyOnce: true,
+			},
+			{
+				Method: http.MethodPost,
+				Path:   "/api/backups",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200,
+					"payload": {
+						"backupId": "backup-id-1"
+					}
+				}`,
+				RunOnlyOnce: true,
+			},
+			{
+				Method: http.MethodGet,
+				Path:   "/api/clusters/cluster-id-1",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200,
+					"payload":{
+						"cluster": {
+							"id": "cluster-id-1",
+							"state": "stopped",
+							"backupPipelineInProgress": false
+						}
+					}
+				}`,
+				RunOnlyOnce: true,
+			},
+			{
+				Method: http.MethodGet,
+				Path:   "/api/backups/backup-id-1",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200,
+					"payload":{
+						"backup": {
+							"backupId": "backup-id-1",
+							"clusterId": "cluster-id-1",
+							"state": "processing"
+						}
+					}
+				}`,
+				RunOnlyOnce: true,
+			},
+			{
+				Method: http.MethodDelete,
+				Path:   "/api/clusters/cluster-id-1",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200
+				}`,
+				RunOnlyOnce: true,
+			},
+			{
+				Method: http.MethodGet,
+				Path:   "/api/clusters/cluster-id-1",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 404
+				}`,
+			},
+		},
+		Resource:             clusterResource(),
+		OperationContextFunc: clusterResource().DeleteContext,
+		Id:                   "cluster-id-1",
+		State: map[string]interface{}{
+			"final_backup": []interface{}{
+				map[string]interface{}{
+					"wait": false,
+				},
+			},
+		},
+		ExpectWarning: "Final backup backup-id-1 created for cluster cluster-id-1",
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClusterDelete_finalBackup_noWait_backupFailed(t *testing.T) {
+	t.Parallel()
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			{
+				Method: http.MethodGet,
+				Path:   "/api/clusters/cluster-id-1",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200,
+					"payload":{
+						"cluster": {
+							"id": "cluster-id-1",
+							"state": "stopped"
+						}
+					}
+				}`,
+				RunOnlyOnce: true,
+			},
+			{
+				Method: http.MethodPost,
+				Path:   "/api/backups",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200,
+					"payload": {
+						"backupId": "backup-id-1"
+					}
+				}`,
+				RunOnlyOnce: true,
+			},
+			{
+				Method: http.MethodGet,
+				Path:   "/api/clusters/cluster-id-1",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200,
+					"payload":{
+						"cluster": {
+							"id": "cluster-id-1",
+							"state": "stopped",
+							"backupPipelineInProgress": false
+						}
+					}
+				}`,
+				RunOnlyOnce: true,
+			},
+			{
+				Method: http.MethodGet,
+				Path:   "/api/backups/backup-id-1",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200,
+					"payload":{
+						"backup": {
+							"backupId": "backup-id-1",
+							"clusterId": "cluster-id-1",
+							"state": "failed",
+							"stateMessage": "failed to backup"
+						}
+					}
+				}`,
+				RunOnlyOnce: true,
+			},
+		},
+		Resource:             clusterResource(),
+		OperationContextFunc: clusterResource().DeleteContext,
+		Id:                   "cluster-id-1",
+		State: map[string]interface{}{
+			"final_backup": []interface{}{
+				map[string]interface{}{
+					"wait": false,
+				},
+			},
+		},
+		ExpectError: "failed to create final backup before deleting the cluster, error: failed while waiting for the backup to start processing: failed to backup",
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClusterDelete_finalBackup_error(t *testing.T) {
+	t.Parallel()
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			{
+				Method: http.MethodGet,
+				Path:   "/api/clusters/cluster-id-1",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200,
+					"payload":{
+						"cluster": {
+							"id": "cluster-id-1",
+							"state": "stopped"
+						}
+					}
+				}`,
+			},
+			{
+				Method: http.MethodPost,
+				Path:   "/api/backups",
+				Response: `{
+					"apiVersion": "v1",
+					"status": "error",
+					"code": 400,
+					"message": "cannot create backup"
+				}`,
+			},
+		},
+		Resource:             clusterResource(),
+		OperationContextFunc: clusterResource().DeleteContext,
+		Id:                   "cluster-id-1",
+		State: map[string]interface{}{
+			"final_backup": []interface{}{
+				map[string]interface{}{
+					"enabled": true,
+				},
+			},
+		},
+		ExpectError: "failed to create final backup before deleting the cluster, error: cannot create backup",
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestClusterDelete_deletionProtection(t *testing.T) {
+	t.Parallel()
+	r := test.ResourceFixture{
+		Resource:             clusterResource(),
+		OperationContextFunc: clusterResource().DeleteContext,
+		Id:                   "cluster-id-1",
+		State: map[string]interface{}{
+			"deletion_protection": true,
+		},
+		ExpectError: "cannot delete cluster cluster-id-1 while deletion_protection is enabled, set deletion_protection to false and apply the change first",
+	}
+	r.Apply(t, context.TODO())
+}
+
+func testClusterDeletionProtectionDiff(t *testing.T, deletionProtection bool, config map[string]interface{}) error {
+	baseConfig := map[string]interface{}{
+		"name":    "cluster-name-1",
+		"version": "3.9.0",
+		"head": []interface{}{
+			map[string]interface{}{
+				"instance_type": "node-type-1",
+			},
+		},
+		"rondb": []interface{}{
+			map[string]interface{}{
+				"single_node": []interface{}{
+					map[string]interface{}{
+						"instance_type": "node-type-2",
+					},
+				},
+			},
+		},
+		"deletion_protection": deletionProtection,
+	}
+	data := schema.TestResourceDataRaw(t, clusterResource().Schema, baseConfig)
+	data.SetId("cluster-id-1")
+
+	for k, v := range config {
+		baseConfig[k] = v
+	}
+	_, err := clusterResource().Diff(context.TODO(), data.State(), terraformSDK.NewResourceConfigRaw(baseConfig), nil)
+	return err
+}
+
+func TestClusterDeletionProtection_forceNewChange(t *testing.T) {
+	t.Parallel()
+	err := testClusterDeletionProtectionDiff(t, true, map[string]interface{}{
+		"name": "cluster-name-2",
+	})
+	expected := "cannot apply the change on name as it requires replacing the cluster while deletion_protection is enabled, set deletion_protection to false and apply the change first"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected error %s but got %#v", expected, err)
+	}
+}
+
+func TestClusterDeletionProtection_forceNewChange_nested(t *testing.T) {
+	t.Parallel()
+	err := testClusterDeletionProtectionDiff(t, true, map[string]interface{}{
+		"head": []interface{}{
+			map[string]interface{}{
+				"instance_type": "node-type-1",
+				"disk_size":     1024,
+			},
+		},
+	})
+	expected := "cannot apply the change on head.0.disk_size as it requires replacing the cluster while deletion_protection is enabled, set deletion_protection to false and apply the change first"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected error %s but got %#v", expected, err)
+	}
+}
+
+func TestClusterDeletionProtection_inPlaceChange(t *testing.T) {
+	t.Parallel()
+	if err := testClusterDeletionProtectionDiff(t, true, map[string]interface{}{
+		"version": "3.9.1",
+		"head": []interface{}{
+			map[string]interface{}{
+				"instance_type": "node-type-3",
+			},
+		},
+	}); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+}
+
+func TestClusterDeletionProtection_disabled(t *testing.T) {
+	t.Parallel()
+	if err := testClusterDeletionProtectionDiff(t, false, map[string]interface{}{
+		"name": "cluster-name-2",
+	}); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+}
+
+func TestClusterDeletionProtection_disableWithForceNewChange(t *testing.T) {
+	t.Parallel()
+	err := testClusterDeletionProtectionDiff(t, true, map[string]interface{}{
+		"name":                "cluster-name-2",
+		"deletion_protection": false,
+	})
+	if err == nil {
+		t.Fatal("expected an error when disabling deletion_protection together with a change that requires replacement")
+	}
+}
+
+const autoUpgradeSupportedVersionsResponse = `{
+	"apiVersion": "v1",
+	"status": "ok",
+	"code": 200,
+	"payload": {
+		"versions":[
+			{
+				"version": "3.7.0",
+				"upgradableFromVersion": "3.6.0",
+				"default": false,
+				"experimental": false
+			},
+			{
+				"version": "3.8.0",
+				"upgradableFromVersion": "3.7.0",
+				"default": false,
+				"experimental": false
+			},
+			{
+				"version": "3.8.1",
+				"upgradableFromVersion": "3.8.0",
+				"default": false,
+				"experimental": false
+			},
+			{
+				"version": "3.8.2",
+				"upgradableFromVersion": "3.8.0",
+				"default": false,
+				"experimental": false
+			},
+			{
+				"version": "3.9.0",
+				"upgradableFromVersion": "3.8.0",
+				"default": true,
+				"experimental": false
+			},
+			{
+				"version": "3.10.0",
+				"upgradableFromVersion": "3.8.0",
+				"default": false,
+				"experimental": true
+			},
+			{
+				"version": "4.0.0",
+				"upgradableFromVersion": "3.8.0",
+				"default": false,
+				"experimental": false
+			}
+		]
+	}
+}`
+
+func TestGetAutoUpgradeVersion(t *testing.T) {
+	var response api.GetSupportedVersionsResponse
+	if err := json.Unmarshal([]byte(autoUpgradeSupportedVersionsResponse), &response); err != nil {
+		t.Fatal(err)
+	}
+	supportedVersions := response.Payload.Versions
+
+	cases := []struct {
+		currentVersion string
+		policy         string
+		expected       string
+	}{
+		{"3.8.0", autoUpgradePolicyPatch, "3.8.2"},
+		{"3.8.0", autoUpgradePolicyMinor, "3.9.0"},
+		{"3.7.0", autoUpgradePolicyPatch, ""},
+		{"3.7.0", autoUpgradePolicyMinor, "3.8.0"},
+		{"3.6.0", autoUpgradePolicyPatch, ""},
+		{"3.9.0", autoUpgradePolicyMinor, ""},
+		{"invalid", autoUpgradePolicyMinor, ""},
+	}
+
+	for _, c := range cases {
+		if output := getAutoUpgradeVersion(c.currentVersion, c.policy, supportedVersions); output != c.expected {
+			t.Fatalf("error while matching %s with policy %s:\nexpected %#v \nbut got %#v", c.currentVersion, c.policy, c.expected, output)
+		}
+	}
+}
+
+func TestIsInMaintenanceWindow(t *testing.T) {
+	// 2024-10-06 is a Sunday
+	cases := []struct {
+		now       time.Time
+		days      []string
+		startTime string
+		duration  int
+		expected  bool
+	}{
+		{time.Date(2024, 10, 6, 2, 30, 0, 0, time.UTC), nil, "02:00", 4, true},
+		{time.Date(2024, 10, 6, 1, 59, 0, 0, time.UTC), nil, "02:00", 4, false},
+		{time.Date(2024, 10, 6, 6, 0, 0, 0, time.UTC), nil, "02:00", 4, false},
+		{time.Date(2024, 10, 6, 2, 30, 0, 0, time.UTC), []string{"sunday"}, "02:00", 4, true},
+		{time.Date(2024, 10, 6, 2, 30, 0, 0, time.UTC), []string{"monday", "saturday"}, "02:00", 4, false},
+		{time.Date(2024, 10, 7, 1, 0, 0, 0, time.UTC), []string{"sunday"}, "22:00", 4, true},
+		{time.Date(2024, 10, 7, 23, 0, 0, 0, time.UTC), []string{"sunday"}, "22:00", 4, false},
+		{time.Date(2024, 10, 7, 2, 0, 0, 0, time.UTC), []string{"sunday"}, "22:00", 4, false},
+	}
+
+	for i, c := range cases {
+		if output := isInMaintenanceWindow(c.now, c.days, c.startTime, c.duration); output != c.expected {
+			t.Fatalf("error while matching case %d:\nexpected %#v \nbut got %#v", i, c.expected, output)
+		}
+	}
+}
+
+func TestClusterAutoUpgrade_proposeUpgrade(t *testing.T) {
+	t.Parallel()
+	diff, err := testClusterAutoUpgradeDiff(t, time.Date(2024, 10, 6, 2, 30, 0, 0, time.UTC), "3.8.0", map[string]interface{}{
+		"auto_upgrade": []interface{}{
+			map[string]interface{}{
+				"policy": autoUpgradePolicyPatch,
+				"maintenance_window": []interface{}{
+					map[string]interface{}{
+						"days":       []interface{}{"sunday"},
+						"start_time": "02:00",
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if diff == nil || diff.Attributes["auto_upgrade_target_version"] == nil || diff.Attributes["auto_upgrade_target_version"].New != "3.8.2" {
+		t.Fatalf("expected auto_upgrade_target_version to change to 3.8.2 but got %#v", diff)
+	}
+	if _, ok := diff.Attributes["version"]; ok {
+		t.Fatalf("unexpected change on version %#v", diff.Attributes["version"])
+	}
+}
+
+func TestClusterAutoUpgrade_outsideMaintenanceWindow(t *testing.T) {
+	t.Parallel()
+	diff, err := testClusterAutoUpgradeDiff(t, time.Date(2024, 10, 7, 2, 30, 0, 0, time.UTC), "3.8.0", map[string]interface{}{
+		"auto_upgrade": []interface{}{
+			map[string]interface{}{
+				"policy": autoUpgradePolicyMinor,
+				"maintenance_window": []interface{}{
+					map[string]interface{}{
+						"days":       []interface{}{"sunday"},
+						"start_time": "02:00",
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if diff != nil {
+		if _, ok := diff.Attributes["auto_upgrade_target_version"]; ok {
+			t.Fatalf("unexpected upgrade outside of the maintenance window %#v", diff)
+		}
+	}
+}
+
+func TestClusterAutoUpgrade_explicitVersionChange(t *testing.T) {
+	t.Parallel()
+	diff, err := testClusterAutoUpgradeDiff(t, time.Now(), "3.8.0", map[string]interface{}{
+		"version": "4.0.0",
+		"auto_upgrade": []interface{}{
+			map[string]interface{}{
+				"policy": autoUpgradePolicyMinor,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if diff == nil || diff.Attributes["version"] == nil || diff.Attributes["version"].New != "4.0.0" {
+		t.Fatalf("expected version to change to 4.0.0 but got %#v", diff)
+	}
+	if _, ok := diff.Attributes["auto_upgrade_target_version"]; ok {
+		t.Fatalf("unexpected auto upgrade with an explicit version change %#v", diff)
+	}
+}
+
+func TestClusterAutoUpgrade_suppressRollbackToConfiguredVersion(t *testing.T) {
+	t.Parallel()
+	diff, err := testClusterAutoUpgradeDiff(t, time.Now(), "3.9.0", map[string]interface{}{
+		"version": "3.8.0",
+		"auto_upgrade": []interface{}{
+			map[string]interface{}{
+				"policy": autoUpgradePolicyMinor,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if diff != nil {
+		if _, ok := diff.Attributes["version"]; ok {
+			t.Fatalf("unexpected change on version %#v", diff.Attributes["version"])
+		}
+	}
+}
+
+func TestClusterAutoUpgrade_noSuppressOutsidePolicy(t *testing.T) {
+	t.Parallel()
+	_, err := testClusterAutoUpgradeDiff(t, time.Now(), "3.9.0", map[string]interface{}{
+		"version": "3.8.0",
+		"auto_upgrade": []interface{}{
+			map[string]interface{}{
+				"policy": autoUpgradePolicyPatch,
+			},
+		},
+	})
+	expected := "cannot change version from 3.9.0 to 3.8.0 as downgrading a cluster is not supported, set version to 3.9.0 or a newer version"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected error %s but got %v", expected, err)
+	}
+}
+
+func TestClusterAutoUpgrade_removeAutoUpgrade(t *testing.T) {
+	t.Parallel()
+	diff, err := testClusterAutoUpgradeDiff(t, time.Now(), "3.9.0", map[string]interface{}{
+		"version": "3.8.0",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if diff == nil || diff.Attributes["version"] == nil || diff.Attributes["version"].New != "3.8.0" {
+		t.Fatalf("expected version to change to 3.8.0 but got %#v", diff)
+	}
+}
+
+func testClusterAutoUpgradeDiff(t *testing.T, now time.Time, stateVersion string, config map[string]interface{}) (*terraformSDK.InstanceDiff, error) {
+	baseConfig := map[string]interface{}{
+		"name":    "cluster-name-1",
+		"version": stateVersion,
+		"head": []interface{}{
+			map[string]interface{}{
+				"instance_type": "node-type-1",
+			},
+		},
+		"aws_attributes": []interface{}{
+			map[string]interface{}{
+				"region":               "region-1",
+				"instance_profile_arn": "arn:aws:iam::123456789101:instance-profile/profile",
+				"bucket": []interface{}{
+					map[string]interface{}{
+						"name": "bucket-1",
+					},
+				},
+			},
+		},
+		"rondb": []interface{}{
+			map[string]interface{}{
+				"single_node": []interface{}{
+					map[string]interface{}{
+						"instance_type": "node-type-2",
+					},
+				},
+			},
+		},
+	}
+	r := clusterResource()
+	r.CustomizeDiff = customdiff.All(
+		resourceClusterDeletionProtectionCustomizeDiff(r.Schema),
+		resourceClusterDowngradeCustomizeDiff,
+		resourceClusterAutoUpgradeCustomizeDiff(func() time.Time { return now }),
+	)
+	data := schema.TestResourceDataRaw(t, r.Schema, baseConfig)
+	data.SetId("cluster-id-1")
+
+	for k, v := range config {
+		baseConfig[k] = v
+	}
+	client := &api.HopsworksAIClient{
+		Client: &apitest.HttpClientFixture{
+			ExpectMethod: http.MethodGet,
+			ExpectPath:   "/api/clusters/hopsworks/versions/AWS",
+			ResponseBody: autoUpgradeSupportedVersionsResponse,
+			ResponseCode: http.StatusOK,
+			T:            t,
+		},
+	}
+	return r.Diff(context.TODO(), data.State(), terraformSDK.NewResourceConfigRaw(baseConfig), client)
+}
+
+func TestClusterUpdate_autoUpgrade(t *testing.T) {
+	t.Parallel()
+	clusterResponse := func(version string) string {
+		return fmt.Sprintf(`{
+			"apiVersion": "v1",
+			"status": "ok",
+			"code": 200,
+			"payload":{
+				"cluster": {
+					"id": "cluster-id-1",
+					"name": "cluster-name-1",
+					"state" : "running",
+					"provider": "AWS",
+					"version": "%s",
+					"os": "ubuntu",
+					"publicIPAttached": true,
+					"letsEncryptIssued": true,
+					"managedUsers": true,
+					"clusterConfiguration": {
+						"head": {
+							"instanceType": "node-type-1",
+							"diskSize": 512
+						}
+					},
+					"aws": {
+						"region": "region-1",
+						"bucketName": "bucket-1",
+						"instanceProfileArn": "arn:aws:iam::123456789101:instance-profile/profile"
+					},
+					"ports":{
+						"featureStore": false,
+						"onlineFeatureStore": false,
+						"kafka": false,
+						"ssh": false
+					}
+				}
+			}
+		}`, version)
+	}
+	r := test.ResourceFixture{
+		HttpOps: []test.Operation{
+			{
+				Method:   http.MethodGet,
+				Path:     "/api/clusters/hopsworks/versions/AWS",
+				Response: autoUpgradeSupportedVersionsResponse,
+			},
+			{
+				Method:      http.MethodGet,
+				Path:        "/api/clusters/cluster-id-1",
+				Response:    clusterResponse("3.8.0"),
+				RunOnlyOnce: true,
+			},
+			{
+				Method:   http.MethodGet,
+				Path:     "/api/clusters/cluster-id-1",
+				Response: clusterResponse("3.9.0"),
+			},
+			{
+				Method: http.MethodPost,
+				Path:   "/api/clusters/cluster-id-1/upgrade",
+				ExpectRequestBody: `{
+					"version": "3.9.0",
+					"dockerRegistryAccount": "123456789101"
+				}`,
+				Response: `{
+					"apiVersion": "v1",
+					"status": "ok",
+					"code": 200
+				}`,
+				RunOnlyOnce: true,
+			},
+		},
+		Resource:             clusterResource(),
+		OperationContextFunc: clusterResource().UpdateContext,
+		Id:                   "cluster-id-1",
+		Update:               true,
+		State: map[string]interface{}{
+			"name":    "cluster-name-1",
+			"version": "3.8.0",
+			"head": []interface{}{
+				map[string]interface{}{
+					"instance_type": "node-type-1",
+				},
+			},
+			"auto_upgrade": []interface{}{
+				map[string]interface{}{
+					"policy": autoUpgradePolicyMinor,
+				},
+			},
+			"aws_attributes": []interface{}{
+				map[string]interface{}{
+					"region":               "region-1",
+					"instance_profile_arn": "arn:aws:iam::123456789101:instance-profile/profile",
+					"bucket": []interface{}{
+						map[string]interface{}{
+							"name": "bucket-1",
+						},
+					},
+				},
+			},
+			"open_ports": []interface{}{
+				map[string]interface{}{
+					"ssh":                  false,
+					"kafka":                false,
+					"feature_store":        false,
+					"online_feature_store": false,
+				},
+			},
+		},
+		ExpectState: map[string]interface{}{
+			"version":                     "3.9.0",
+			"auto_upgrade_target_version": "3.9.0",
+		},
+	}
+	r.Apply(t, context.TODO())
+}
+
+func TestGetECRRegistryAccountIdFromInstanceProfile(t *testing.T) {
+	cases := map[string]string{
+		"arn:aws:iam::000011112222:instance-profile/my-profile":        "000011112222",
+		"arn:aws-cn:iam::000011112222:instance-profile/my-profile":     "000011112222",
+		"arn:aws-us-gov:iam::000011112222:instance-profile/my-profile": "000011112222",
+		"arn:aws-iso:iam::000011112222:instance-profile/my-profile":    "",
+		"arn:aws:iam::000011112222:role/my-role":                       "",
+	}
+
+	for instanceProfile, expected := range cases {
+		if output := getECRRegistryAccountIdFromInstanceProfile(instanceProfile); output != expected {
+			t.Fatalf("error while matching %s:\nexpected %#v \nbut got %#v", instanceProfile, expected, output)
+		}
+	}
+}